@@ -0,0 +1,216 @@
+package gouwu
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+)
+
+// Accessibility is how faces are written for screen readers
+type Accessibility int
+
+const (
+	// AccessibilityOmit leaves faces out
+	AccessibilityOmit Accessibility = iota + 1
+
+	// AccessibilityDescribe replaces faces with a short description, like
+	// "(smiling face)"
+	AccessibilityDescribe
+
+	// AccessibilityMark wraps faces in the face marker
+	AccessibilityMark
+)
+
+// FaceMarker is written around faces in AccessibilityMark mode
+type FaceMarker struct {
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// DefaultFaceMarker is the face marker of new uwuifiers
+var DefaultFaceMarker = FaceMarker{Open: "<face>", Close: "</face>"}
+
+// builtinDescriptions describes the faces of the built-in packs
+var builtinDescriptions = map[string]string{
+	"(・`ω´・)": "determined face",
+	";;w;;":   "crying face",
+	"OwO":     "surprised face",
+	"UwU":     "happy face",
+	">w<":     "excited face",
+	"^w^":     "smiling face",
+	"ÚwÚ":     "sad face",
+	"^-^":     "smiling face",
+	":3":      "cat face",
+	"x3":      "laughing cat face",
+
+	"owo": "surprised face",
+	"uwu": "happy face",
+	">_<": "squinting face",
+	"T_T": "crying face",
+	";_;": "crying face",
+	"^_^": "smiling face",
+	"o.O": "confused face",
+
+	"🥺":  "pleading face",
+	"👉👈": "fingers pointing together",
+	"😳":  "flushed face",
+	"🥰":  "smiling face with hearts",
+	"😊":  "smiling face",
+	"😭":  "crying face",
+	"😤":  "huffing face",
+	"🙈":  "see-no-evil monkey",
+	"✨":  "sparkles",
+	"💕":  "two hearts",
+}
+
+// String returns the name of the mode, or "" for none
+func (a Accessibility) String() string {
+	switch a {
+	case 0:
+		return ""
+	case AccessibilityOmit:
+		return "omit"
+	case AccessibilityDescribe:
+		return "describe"
+	case AccessibilityMark:
+		return "mark"
+	}
+	return fmt.Sprintf("Accessibility(%d)", int(a))
+}
+
+// MarshalText encodes the mode as its name
+func (a Accessibility) MarshalText() ([]byte, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes a mode from its name
+func (a *Accessibility) UnmarshalText(text []byte) error {
+	for _, mode := range []Accessibility{0, AccessibilityOmit, AccessibilityDescribe, AccessibilityMark} {
+		if mode.String() == string(text) {
+			*a = mode
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown accessibility mode %q, want omit, describe or mark", text)
+}
+
+// validate checks that a is a known mode or none
+func (a Accessibility) validate() error {
+	if a < 0 || a > AccessibilityMark {
+		return errors.New("unknown accessibility mode")
+	}
+	return nil
+}
+
+// String returns the quoted open and close markers
+func (m FaceMarker) String() string {
+	return strconv.Quote(m.Open) + " " + strconv.Quote(m.Close)
+}
+
+// WithAccessibility writes faces for screen readers, see SetAccessibility
+func WithAccessibility(mode Accessibility) Option {
+	return func(u *Uwuifier) {
+		u.SetAccessibility(mode)
+	}
+}
+
+// WithFaceMarker sets the marker written around faces, see SetFaceMarker
+func WithFaceMarker(marker FaceMarker) Option {
+	return func(u *Uwuifier) {
+		u.SetFaceMarker(marker)
+	}
+}
+
+// WithFaceDescriptions describes faces, see SetFaceDescriptions
+func WithFaceDescriptions(descriptions map[string]string) Option {
+	return func(u *Uwuifier) {
+		u.SetFaceDescriptions(descriptions)
+	}
+}
+
+// Accessibility returns how faces are written for screen readers, or 0 if
+// they are written as they are
+func (u *Uwuifier) Accessibility() Accessibility { return u.accessibility }
+
+// FaceMarker returns the marker written around faces in AccessibilityMark mode
+func (u *Uwuifier) FaceMarker() FaceMarker { return u.faceMarker }
+
+// FaceDescriptions returns a copy of the descriptions set with
+// SetFaceDescriptions
+func (u *Uwuifier) FaceDescriptions() map[string]string { return maps.Clone(u.descriptions) }
+
+// DescribeFace returns the description of a face in AccessibilityDescribe
+// mode, without the parentheses around it
+func (u *Uwuifier) DescribeFace(face string) string {
+	if description, ok := u.descriptions[face]; ok {
+		return description
+	}
+	if description, ok := builtinDescriptions[face]; ok {
+		return description
+	}
+	if moods := u.filter().moodsOf(face); len(moods) > 0 {
+		return string(moods[0]) + " face"
+	}
+	return "face"
+}
+
+// SetAccessibility writes faces for screen readers: left out, described or
+// wrapped in the face marker. Any mode also stops stuttering, which makes
+// words unreadable to text to speech. A mode of 0 writes faces as they are,
+// the default.
+func (u *Uwuifier) SetAccessibility(mode Accessibility) error {
+	if err := mode.validate(); err != nil {
+		return err
+	}
+	u.accessibility = mode
+	u.changed()
+	return nil
+}
+
+// SetFaceMarker sets the marker written around faces in AccessibilityMark
+// mode, DefaultFaceMarker by default
+func (u *Uwuifier) SetFaceMarker(marker FaceMarker) error {
+	if marker.Open == "" && marker.Close == "" {
+		return errors.New("face marker must not be empty")
+	}
+	u.faceMarker = marker
+	u.changed()
+	return nil
+}
+
+// SetFaceDescriptions describes faces in AccessibilityDescribe mode,
+// overriding the descriptions of the built-in ones. Faces described
+// neither here nor by default are described by their mood, like "(happy
+// face)", or as "(face)".
+func (u *Uwuifier) SetFaceDescriptions(descriptions map[string]string) error {
+	for face, description := range descriptions {
+		if face == "" {
+			return errors.New("described face must not be empty")
+		}
+		if description == "" {
+			return fmt.Errorf("description of %q must not be empty", face)
+		}
+	}
+	u.descriptions = maps.Clone(descriptions)
+	u.changed()
+	return nil
+}
+
+// appendFace appends face to dst as the accessibility mode writes it
+func (u *Uwuifier) appendFace(dst []byte, face string) []byte {
+	switch u.accessibility {
+	case AccessibilityDescribe:
+		dst = append(dst, '(')
+		dst = append(dst, u.DescribeFace(face)...)
+		return append(dst, ')')
+	case AccessibilityMark:
+		dst = append(dst, u.faceMarker.Open...)
+		dst = append(dst, face...)
+		return append(dst, u.faceMarker.Close...)
+	}
+	return append(dst, face...)
+}
@@ -0,0 +1,103 @@
+package gouwu
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAccessibility(t *testing.T) {
+	faces := WithSpaces(SpacesModifier{Faces: 1})
+
+	testCases := []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{"as is", []Option{faces, WithFaces("UwU")}, "hello UwU"},
+		{"omit", []Option{faces, WithAccessibility(AccessibilityOmit)}, "hello"},
+		{"describe", []Option{faces, WithFaces("UwU"), WithAccessibility(AccessibilityDescribe)}, "hello (happy face)"},
+		{"describe custom", []Option{
+			faces, WithFaces("UwU"), WithAccessibility(AccessibilityDescribe),
+			WithFaceDescriptions(map[string]string{"UwU": "you-woo"}),
+		}, "hello (you-woo)"},
+		{"describe by mood", []Option{
+			faces, WithFaces("(ಥ﹏ಥ)"), WithAccessibility(AccessibilityDescribe),
+			WithMoods(Moods{"(ಥ﹏ಥ)": {MoodSad}}),
+		}, "hello (sad face)"},
+		{"describe unknown", []Option{faces, WithFaces("(ಥ﹏ಥ)"), WithAccessibility(AccessibilityDescribe)}, "hello (face)"},
+		{"mark", []Option{faces, WithFaces("UwU"), WithAccessibility(AccessibilityMark)}, "hello <face>UwU</face>"},
+		{"custom marker", []Option{
+			faces, WithFaces("UwU"), WithAccessibility(AccessibilityMark),
+			WithFaceMarker(FaceMarker{Open: `<span aria-hidden="true">`, Close: "</span>"}),
+		}, `hello <span aria-hidden="true">UwU</span>`},
+		{"actions untouched", []Option{
+			WithSpaces(SpacesModifier{Actions: 1}), WithActions("*blushes*"), WithAccessibility(AccessibilityOmit),
+		}, "hello *blushes*"},
+		{"no stutters", []Option{WithSpaces(SpacesModifier{Stutters: 1}), WithAccessibility(AccessibilityMark)}, "hello"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, opts := range [][]Option{tc.opts, append(tc.opts, WithUpstreamParity())} {
+				if result := New(opts...).UwuifySpaces("hello"); result != tc.expected {
+					t.Errorf("UwuifySpaces(%q) = %q, want %q", "hello", result, tc.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestAccessibilityKeepsOtherWords(t *testing.T) {
+	input := "the quick brown fox jumps over the lazy dog and runs far away"
+	plain := New(WithSpaces(SpacesModifier{Faces: 0.5}))
+	marked := plain.With(WithAccessibility(AccessibilityMark), WithFaceMarker(FaceMarker{Open: "[", Close: "]"}))
+
+	expected := plain.UwuifySentence(input)
+	for _, face := range plain.Faces {
+		expected = strings.ReplaceAll(expected, " "+face, " ["+face+"]")
+	}
+	if result := marked.UwuifySentence(input); result != expected {
+		t.Errorf("UwuifySentence(%q) = %q, want %q", input, result, expected)
+	}
+}
+
+func TestAccessibilityText(t *testing.T) {
+	for _, mode := range []Accessibility{0, AccessibilityOmit, AccessibilityDescribe, AccessibilityMark} {
+		data, err := json.Marshal(mode)
+		if err != nil {
+			t.Fatalf("Marshal(%v) error = %v", mode, err)
+		}
+		var decoded Accessibility
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != mode {
+			t.Errorf("Unmarshal(%s) = %v, %v, want %v", data, decoded, err, mode)
+		}
+	}
+
+	var mode Accessibility
+	if err := mode.UnmarshalText([]byte("loud")); err == nil {
+		t.Error("UnmarshalText() should reject unknown modes")
+	}
+}
+
+func TestAccessibilityValidation(t *testing.T) {
+	uwuifier := New()
+	if err := uwuifier.SetAccessibility(Accessibility(7)); err == nil {
+		t.Error("SetAccessibility() should reject unknown modes")
+	}
+	if err := uwuifier.SetFaceMarker(FaceMarker{}); err == nil {
+		t.Error("SetFaceMarker() should reject an empty marker")
+	}
+	if err := uwuifier.SetFaceDescriptions(map[string]string{"UwU": ""}); err == nil {
+		t.Error("SetFaceDescriptions() should reject empty descriptions")
+	}
+	if uwuifier.FaceMarker() != DefaultFaceMarker {
+		t.Errorf("FaceMarker() = %v, want %v", uwuifier.FaceMarker(), DefaultFaceMarker)
+	}
+
+	for _, face := range New().Faces {
+		if description := uwuifier.DescribeFace(face); description == "face" {
+			t.Errorf("built-in face %q is not described", face)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package gouwu
+
+import (
+	"container/list"
+	"slices"
+	"sync"
+)
+
+// CacheStats reports how a word cache has been used
+type CacheStats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Entries   int    `json:"entries"`
+	Capacity  int    `json:"capacity"`
+}
+
+// cacheEntry is the uwuified form of a single word before its capitalization
+// is adjusted, which is the only part that depends on the surrounding words
+type cacheEntry struct {
+	key        string
+	word       []byte
+	capitalize bool
+}
+
+// wordCache is a bounded LRU cache of uwuified words
+type wordCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+
+	// Snapshots of the expression lists the entries were computed with
+	faces, actions, exclamations []string
+
+	hits, misses, evictions uint64
+}
+
+// WithCache enables a cache of up to size uwuified words, so repeated words
+// skip the transformation stages. The cache is cleared whenever a setter is
+// called or the Faces, Actions or Exclamations lists change. A size of zero
+// or less disables caching.
+func WithCache(size int) Option {
+	return func(u *Uwuifier) {
+		if size <= 0 {
+			u.cache = nil
+			return
+		}
+		u.cache = newWordCache(size)
+	}
+}
+
+// newWordCache creates an empty cache of up to size words
+func newWordCache(size int) *wordCache {
+	return &wordCache{
+		capacity: size,
+		entries:  make(map[string]*list.Element, size),
+		order:    list.New(),
+	}
+}
+
+// CacheStats returns the usage counters of the word cache, or the zero
+// value if caching is disabled
+func (u *Uwuifier) CacheStats() CacheStats {
+	c := u.cache
+	if c == nil {
+		return CacheStats{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Entries:   c.order.Len(),
+		Capacity:  c.capacity,
+	}
+}
+
+// reset drops every cached word
+func (c *wordCache) reset() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clear()
+}
+
+// clear drops every cached word; the caller holds c.mu
+func (c *wordCache) clear() {
+	clear(c.entries)
+	c.order.Init()
+}
+
+// validate clears the cache if the expression lists changed since the
+// cached words were computed
+func (c *wordCache) validate(u *Uwuifier) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if slices.Equal(c.faces, u.Faces) &&
+		slices.Equal(c.actions, u.Actions) &&
+		slices.Equal(c.exclamations, u.Exclamations) {
+		return
+	}
+
+	c.clear()
+	c.faces = slices.Clone(u.Faces)
+	c.actions = slices.Clone(u.Actions)
+	c.exclamations = slices.Clone(u.Exclamations)
+}
+
+// appendWord appends the cached form of word to dst
+func (c *wordCache) appendWord(dst, word []byte) ([]byte, bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[string(word)]
+	if !ok {
+		c.misses++
+		return dst, false, false
+	}
+
+	c.hits++
+	c.order.MoveToFront(elem)
+	entry := elem.Value.(*cacheEntry)
+	return append(dst, entry.word...), entry.capitalize, true
+}
+
+// store records the uwuified form of word, evicting the least recently
+// used word if the cache is full
+func (c *wordCache) store(word, result []byte, capitalize bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[string(word)]; ok {
+		return
+	}
+
+	if c.order.Len() >= c.capacity {
+		oldest := c.order.Back()
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+		c.order.Remove(oldest)
+		c.evictions++
+	}
+
+	entry := &cacheEntry{
+		key:        string(word),
+		word:       slices.Clone(result),
+		capitalize: capitalize,
+	}
+	c.entries[entry.key] = c.order.PushFront(entry)
+}
@@ -0,0 +1,103 @@
+package gouwu
+
+import "testing"
+
+func TestCacheMatchesUncached(t *testing.T) {
+	spaces := SpacesModifier{Faces: 0.3, Actions: 0.3, Stutters: 0.4}
+	uncached := New(WithSpaces(spaces))
+	cached := New(WithSpaces(spaces), WithCache(8))
+
+	for _, tc := range readGolden(t, "testdata/golden_v1.txt") {
+		// Run twice so the second pass is served from the cache
+		for range 2 {
+			result := cached.UwuifySentence(tc.Input)
+			expected := uncached.UwuifySentence(tc.Input)
+			if result != expected {
+				t.Fatalf("cached UwuifySentence(%q) = %q, want %q", tc.Input, result, expected)
+			}
+		}
+	}
+
+	if stats := cached.CacheStats(); stats.Hits == 0 || stats.Evictions == 0 {
+		t.Errorf("CacheStats() = %+v, want hits and evictions", stats)
+	}
+}
+
+func TestCacheStats(t *testing.T) {
+	uwuifier := New(WithCache(2))
+
+	uwuifier.UwuifySentence("the cat the dog")
+
+	stats := uwuifier.CacheStats()
+	expected := CacheStats{Hits: 1, Misses: 3, Evictions: 1, Entries: 2, Capacity: 2}
+	if stats != expected {
+		t.Errorf("CacheStats() = %+v, want %+v", stats, expected)
+	}
+
+	if stats := New().CacheStats(); stats != (CacheStats{}) {
+		t.Errorf("CacheStats() without cache = %+v, want zero value", stats)
+	}
+}
+
+func TestCacheOnlyCoversSentences(t *testing.T) {
+	uwuifier := New(WithCache(16))
+
+	uwuifier.UwuifyWords("hello hello")
+	uwuifier.UwuifySpaces("hello hello")
+	uwuifier.UwuifyExclamations("hello hello")
+
+	if stats := uwuifier.CacheStats(); stats.Hits+stats.Misses != 0 {
+		t.Errorf("single stages used the cache: %+v", stats)
+	}
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	uwuifier := New(WithCache(16), WithSpaces(SpacesModifier{Faces: 1}))
+	uwuifier.UwuifySentence("hello")
+
+	uwuifier.SetWordsModifier(0)
+	if result := uwuifier.UwuifySentence("hello"); result != "hello :3" {
+		t.Errorf("after SetWordsModifier(0) got %q, want %q", result, "hello :3")
+	}
+
+	uwuifier.Faces = []string{"owo"}
+	if result := uwuifier.UwuifySentence("hello"); result != "hello owo" {
+		t.Errorf("after replacing Faces got %q, want %q", result, "hello owo")
+	}
+
+	uwuifier.Faces[0] = "^w^"
+	if result := uwuifier.UwuifySentence("hello"); result != "hello ^w^" {
+		t.Errorf("after editing Faces got %q, want %q", result, "hello ^w^")
+	}
+
+	if stats := uwuifier.CacheStats(); stats.Hits != 0 {
+		t.Errorf("stale entries were served: %+v", stats)
+	}
+}
+
+func TestCacheCapitalization(t *testing.T) {
+	uwuifier := New(WithCache(16), WithWords(0), WithSpaces(SpacesModifier{Faces: 1}))
+
+	// The same word is lowered after a full stop but not after a comma
+	result := uwuifier.UwuifySentence("Hi. Hi, Hi")
+	expected := uwuifier.UwuifySentence("Hi. Hi, Hi")
+	if result != expected {
+		t.Fatalf("cached output changed between calls: %q vs %q", result, expected)
+	}
+
+	uncached := New(WithWords(0), WithSpaces(SpacesModifier{Faces: 1}))
+	if want := uncached.UwuifySentence("Hi. Hi, Hi"); result != want {
+		t.Errorf("UwuifySentence() = %q, want %q", result, want)
+	}
+}
+
+func BenchmarkUwuifySentenceCached(b *testing.B) {
+	uwuifier := New(WithCache(256))
+	sentence := "Hello world! This is a test sentence with https://example.com and @someone, really?!"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		uwuifier.UwuifySentence(sentence)
+	}
+}
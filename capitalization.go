@@ -0,0 +1,173 @@
+package gouwu
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Capitalization is how the uwuifier treats capital letters
+type Capitalization int
+
+const (
+	// CapitalizationLegacy lowers the capital starting a word after a face
+	// or action was added to it, if it starts the sentence or follows a
+	// word ending in '.', '!', '?' or '-', unless most of its letters are
+	// capitals. It is the default, as the original port did.
+	CapitalizationLegacy Capitalization = iota
+
+	// CapitalizationKeep never changes the case of a letter
+	CapitalizationKeep
+
+	// CapitalizationLower lowercases every word
+	CapitalizationLower
+
+	// CapitalizationSentence lowercases every word and capitalizes the
+	// first one of each sentence
+	CapitalizationSentence
+)
+
+// String returns the name of the policy
+func (c Capitalization) String() string {
+	switch c {
+	case CapitalizationLegacy:
+		return "legacy"
+	case CapitalizationKeep:
+		return "keep"
+	case CapitalizationLower:
+		return "lowercase"
+	case CapitalizationSentence:
+		return "sentence"
+	}
+	return fmt.Sprintf("Capitalization(%d)", int(c))
+}
+
+// MarshalText encodes the policy as its name
+func (c Capitalization) MarshalText() ([]byte, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText decodes a policy from its name, with "" being legacy
+func (c *Capitalization) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*c = CapitalizationLegacy
+		return nil
+	}
+	for _, policy := range []Capitalization{CapitalizationLegacy, CapitalizationKeep, CapitalizationLower, CapitalizationSentence} {
+		if policy.String() == string(text) {
+			*c = policy
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown capitalization %q, want legacy, keep, lowercase or sentence", text)
+}
+
+// validate checks that c is a known policy
+func (c Capitalization) validate() error {
+	if c < CapitalizationLegacy || c > CapitalizationSentence {
+		return errors.New("unknown capitalization")
+	}
+	return nil
+}
+
+// WithCapitalization sets the capitalization policy, see SetCapitalization
+func WithCapitalization(policy Capitalization) Option {
+	return func(u *Uwuifier) {
+		u.SetCapitalization(policy)
+	}
+}
+
+// Capitalization returns the capitalization policy
+func (u *Uwuifier) Capitalization() Capitalization { return u.capitalization }
+
+// SetCapitalization sets how the uwuifier treats capital letters. The
+// lowercase and sentence policies leave "I" and its contractions, acronyms
+// like "NASA", protected words, links and mentions as they are.
+func (u *Uwuifier) SetCapitalization(policy Capitalization) error {
+	if err := policy.validate(); err != nil {
+		return err
+	}
+	u.capitalization = policy
+	u.changed()
+	return nil
+}
+
+// lowerWord lowercases sc.word unless it is an exception to the lowercase
+// and sentence policies
+func lowerWord(sc *scratch) {
+	if isURI(sc.word) || isAt(sc.word) {
+		return
+	}
+
+	start, end := wordCore(sc.word)
+	core := sc.word[start:end]
+	if isPronounI(core) || isAcronym(core) {
+		return
+	}
+
+	sc.spare = appendFold(sc.spare[:0], sc.word)
+	sc.word, sc.spare = sc.spare, sc.word
+}
+
+// isPronounI reports whether core is "I" or a contraction of it, like "I'm"
+func isPronounI(core []byte) bool {
+	if len(core) == 0 || core[0] != 'I' {
+		return false
+	}
+	rest := core[1:]
+	if len(rest) == 0 {
+		return true
+	}
+	r, _ := utf8.DecodeRune(rest)
+	return r == '\'' || r == '’'
+}
+
+// isAcronym reports whether core has at least two letters and all of them
+// are capitals
+func isAcronym(core []byte) bool {
+	letters := 0
+	for i := 0; i < len(core); {
+		r, size := utf8.DecodeRune(core[i:])
+		i += size
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		if !unicode.IsUpper(r) {
+			return false
+		}
+		letters++
+	}
+	return letters >= 2
+}
+
+// capitalizeFirst capitalizes the first letter of the word starting dst at
+// wordStart, after any punctuation. Links and mentions are told from sc.word,
+// since faces and actions may follow them in dst.
+func capitalizeFirst(sc *scratch, dst []byte, wordStart int) []byte {
+	if isURI(sc.word) || isAt(sc.word) {
+		return dst
+	}
+
+	word := dst[wordStart:]
+	start, _ := wordCore(word)
+	r, size := utf8.DecodeRune(word[start:])
+	if !unicode.IsLower(r) {
+		return dst
+	}
+
+	i := wordStart + start
+	sc.spare = append(sc.spare[:0], dst[i+size:]...)
+	dst = utf8.AppendRune(dst[:i], unicode.ToUpper(r))
+	return append(dst, sc.spare...)
+}
+
+// endsWithSentence reports whether word ends a sentence, so the word after
+// it starts one
+func endsWithSentence(word []byte) bool {
+	_, end := wordCore(word)
+	return end < len(word) && endsSentence(word[end:])
+}
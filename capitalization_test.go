@@ -0,0 +1,91 @@
+package gouwu
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCapitalization(t *testing.T) {
+	quiet := []Option{WithWords(0), WithExclamations(0), WithSpaces(SpacesModifier{})}
+	faces := []Option{WithWords(0), WithExclamations(0), WithSpaces(SpacesModifier{Faces: 1}), WithFaces("UwU")}
+
+	testCases := []struct {
+		name     string
+		opts     []Option
+		input    string
+		expected string
+	}{
+		{"legacy", faces, "Hello. The End", "hello. UwU The UwU End UwU"},
+		{"keep", append(faces, WithCapitalization(CapitalizationKeep)), "Hello. The End", "Hello. UwU The UwU End UwU"},
+		{"legacy non-ASCII v2", append(faces, WithAlgorithmVersion(AlgorithmV2)), "Ñandú. Émile", "ñandú. UwU Émile UwU"},
+		{"legacy non-ASCII v3", append(faces, WithAlgorithmVersion(AlgorithmV3)), "Ñandú Thing", "ñandú UwU Thing UwU"},
+		{"lowercase", append(quiet, WithCapitalization(CapitalizationLower)), "Hello. The End, Émile", "hello. the end, émile"},
+		{"lowercase faces", append(faces, WithCapitalization(CapitalizationLower)), "Hello World", "hello UwU world UwU"},
+		{"lowercase exceptions", append(quiet, WithCapitalization(CapitalizationLower), WithProtectedWords("Gopher")),
+			"I think I'm at NASA, Gopher. Read https://Go.dev @Alice", "I think I'm at NASA, Gopher. read https://Go.dev @Alice"},
+		{"sentence", append(quiet, WithCapitalization(CapitalizationSentence)),
+			"hello THERE friend. how ARE you? \"fine!\" i SAID", "Hello THERE friend. How ARE you? \"Fine!\" I SAID"},
+		{"sentence lowers", append(quiet, WithCapitalization(CapitalizationSentence)),
+			"Hello There. The End", "Hello there. The end"},
+		{"sentence faces", append(faces, WithCapitalization(CapitalizationSentence)), "hi. Bye", "Hi. UwU Bye UwU"},
+		{"sentence exceptions", append(quiet, WithCapitalization(CapitalizationSentence), WithProtectedWords("gopher")),
+			"gopher. @alice. https://go.dev", "gopher. @alice. https://go.dev"},
+		{"sentence link with face", append(faces, WithCapitalization(CapitalizationSentence)),
+			"https://example.com is up. @alice said so", "https://example.com UwU is UwU up. UwU @alice UwU said UwU so UwU"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uwuifier := New(tc.opts...)
+			for range 2 {
+				if result := uwuifier.UwuifySentence(tc.input); result != tc.expected {
+					t.Errorf("UwuifySentence(%q) = %q, want %q", tc.input, result, tc.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestCapitalizationCache(t *testing.T) {
+	input := "the end. the end"
+	uwuifier := New(WithCache(16), WithCapitalization(CapitalizationSentence), WithWords(0), WithSpaces(SpacesModifier{}))
+	for range 3 {
+		if result := uwuifier.UwuifySentence(input); result != "The end. The end" {
+			t.Errorf("UwuifySentence(%q) = %q, want %q", input, result, "The end. The end")
+		}
+	}
+}
+
+func TestCapitalizationText(t *testing.T) {
+	for _, policy := range []Capitalization{CapitalizationLegacy, CapitalizationKeep, CapitalizationLower, CapitalizationSentence} {
+		data, err := json.Marshal(policy)
+		if err != nil {
+			t.Fatalf("Marshal(%v) error = %v", policy, err)
+		}
+		var decoded Capitalization
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != policy {
+			t.Errorf("Unmarshal(%s) = %v, %v, want %v", data, decoded, err, policy)
+		}
+	}
+
+	var policy Capitalization
+	if err := policy.UnmarshalText([]byte("upper")); err == nil || !strings.Contains(err.Error(), "legacy, keep, lowercase or sentence") {
+		t.Errorf("UnmarshalText() error = %v, want the known policies", err)
+	}
+	if err := New().SetCapitalization(7); err == nil {
+		t.Error("SetCapitalization(7) succeeded")
+	}
+
+	config, err := ParseConfig([]byte(`{"capitalization": "lowercase"}`))
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	uwuifier, err := NewFromConfig(config)
+	if err != nil {
+		t.Fatalf("NewFromConfig() error = %v", err)
+	}
+	if policy := uwuifier.Capitalization(); policy != CapitalizationLower {
+		t.Errorf("Capitalization() = %v, want %v", policy, CapitalizationLower)
+	}
+}
@@ -0,0 +1,301 @@
+package gouwu
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+)
+
+// Modifiers holds the probabilities of the transformation stages
+type Modifiers struct {
+	Words        float64            `json:"words"`
+	Spaces       SpacesModifier     `json:"spaces"`
+	Exclamations float64            `json:"exclamations"`
+	Elongation   ElongationModifier `json:"elongation"`
+	Nya          NyaModifier        `json:"nya"`
+}
+
+// Config is the serializable form of an Uwuifier. It decodes strictly,
+// rejecting unknown fields, and fields missing from a file parsed with
+// ParseConfig or LoadConfig keep their default values. The random source
+// is not part of the configuration.
+type Config struct {
+	// Version is the algorithm version, where 0 means DefaultAlgorithmVersion
+	Version   AlgorithmVersion `json:"version"`
+	Modifiers Modifiers        `json:"modifiers"`
+
+	// StutterCounts weighs how many times stutters repeat, where nil keeps
+	// the default of the algorithm version
+	StutterCounts []float64 `json:"stutter_counts,omitempty"`
+
+	Faces        []string `json:"faces"`
+	Actions      []string `json:"actions"`
+	Exclamations []string `json:"exclamations"`
+
+	FaceWeights        Weights `json:"face_weights,omitempty"`
+	ActionWeights      Weights `json:"action_weights,omitempty"`
+	ExclamationWeights Weights `json:"exclamation_weights,omitempty"`
+
+	// Rating is the highest rating that may be picked, where 0 means any
+	Rating  Rating  `json:"rating,omitempty"`
+	Ratings Ratings `json:"ratings,omitempty"`
+
+	// ASCIIOnly guarantees that nothing inserted contains non-ASCII runes
+	ASCIIOnly bool `json:"ascii_only,omitempty"`
+
+	// Sentiment is the lexicon sentence moods are detected with, where nil
+	// turns detection off
+	Moods     Moods   `json:"moods,omitempty"`
+	Sentiment Lexicon `json:"sentiment,omitempty"`
+
+	// TriggerLimit caps the triggered actions per sentence, where 0 turns
+	// triggers off
+	Triggers     []Trigger `json:"triggers,omitempty"`
+	TriggerLimit int       `json:"trigger_limit"`
+
+	// Accessibility is how faces are written for screen readers, where 0
+	// writes them as they are
+	Accessibility    Accessibility     `json:"accessibility,omitempty"`
+	FaceMarker       FaceMarker        `json:"face_marker"`
+	FaceDescriptions map[string]string `json:"face_descriptions,omitempty"`
+
+	// Capitalization is how capital letters are treated, where the zero
+	// value is CapitalizationLegacy
+	Capitalization Capitalization `json:"capitalization,omitempty"`
+
+	// Owoify is the owoify level whose mappings replace the built-in word
+	// rules, where 0 keeps them
+	Owoify         OwoifyLevel       `json:"owoify,omitempty"`
+	Phonetics      Phonetics         `json:"phonetics"`
+	Rules          []Rule            `json:"rules,omitempty"`
+	Dictionary     map[string]string `json:"dictionary,omitempty"`
+	ProtectedWords []string          `json:"protected_words,omitempty"`
+
+	// Cache is the size of the word cache, where 0 disables it
+	Cache          int  `json:"cache,omitempty"`
+	UpstreamParity bool `json:"upstream_parity,omitempty"`
+
+	// Personas are the personas the uwuifier can speak as next to the
+	// built-in ones, see Persona
+	Personas map[string]Persona `json:"personas,omitempty"`
+
+	// Profiles are named variants of this configuration, see Profile
+	Profiles map[string]Profile `json:"profiles,omitempty"`
+}
+
+// DefaultConfig returns the configuration of New()
+func DefaultConfig() Config {
+	return New().Config()
+}
+
+// ParseConfig decodes a JSON configuration over the defaults and validates it
+func ParseConfig(data []byte) (Config, error) {
+	return parseConfig(DefaultConfig(), data)
+}
+
+// LoadConfig reads and parses the JSON configuration file at path
+func LoadConfig(path string) (Config, error) {
+	return loadConfig(DefaultConfig(), path)
+}
+
+// parseConfig decodes a JSON configuration over base and validates it
+func parseConfig(base Config, data []byte) (Config, error) {
+	c := base.clone()
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Config{}, fmt.Errorf("config: %w", err)
+	}
+	if err := c.Validate(); err != nil {
+		return Config{}, err
+	}
+	return c, nil
+}
+
+// loadConfig reads and parses the JSON configuration file at path over base
+func loadConfig(base Config, path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	c, err := parseConfig(base, data)
+	if err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+// clone returns a copy of the configuration that shares no slices or maps
+// with it, so decoding over the copy leaves c untouched
+func (c Config) clone() Config {
+	c.Faces = slices.Clone(c.Faces)
+	c.Actions = slices.Clone(c.Actions)
+	c.Exclamations = slices.Clone(c.Exclamations)
+	c.FaceWeights = maps.Clone(c.FaceWeights)
+	c.ActionWeights = maps.Clone(c.ActionWeights)
+	c.ExclamationWeights = maps.Clone(c.ExclamationWeights)
+	c.Ratings = maps.Clone(c.Ratings)
+	c.Moods = maps.Clone(c.Moods)
+	c.Sentiment = maps.Clone(c.Sentiment)
+	c.Triggers = slices.Clone(c.Triggers)
+	c.FaceDescriptions = maps.Clone(c.FaceDescriptions)
+	c.StutterCounts = slices.Clone(c.StutterCounts)
+	c.Rules = slices.Clone(c.Rules)
+	c.Dictionary = maps.Clone(c.Dictionary)
+	c.ProtectedWords = slices.Clone(c.ProtectedWords)
+	c.Personas = maps.Clone(c.Personas)
+	c.Profiles = maps.Clone(c.Profiles)
+	return c
+}
+
+// Save writes the configuration to path as indented JSON
+func (c Config) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// UnmarshalJSON decodes a configuration, rejecting unknown fields. Fields
+// missing from data are left as they are.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	type config Config
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode((*config)(c))
+}
+
+// Validate reports every problem with the configuration and its profiles
+func (c Config) Validate() error {
+	errs := []error{c.apply(New(), "")}
+	for _, name := range slices.Sorted(maps.Keys(c.Profiles)) {
+		resolved, err := c.ResolveProfile(name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("config: profiles.%s: %w", name, err))
+			continue
+		}
+		errs = append(errs, resolved.apply(New(), "profiles."+name+"."))
+	}
+	return errors.Join(errs...)
+}
+
+// NewFromConfig creates an Uwuifier from the top level of a configuration,
+// applying opts after it
+func NewFromConfig(c Config, opts ...Option) (*Uwuifier, error) {
+	u := New()
+	if err := c.apply(u, ""); err != nil {
+		return nil, err
+	}
+
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u, nil
+}
+
+// Config returns the configuration of the uwuifier
+func (u *Uwuifier) Config() Config {
+	c := Config{
+		Version: u.algorithm,
+		Modifiers: Modifiers{
+			Words:        u.wordsModifier,
+			Spaces:       u.spacesModifier,
+			Exclamations: u.exclamationsModifier,
+			Elongation:   u.elongationModifier,
+			Nya:          u.nyaModifier,
+		},
+		StutterCounts:      u.StutterCounts(),
+		Faces:              slices.Clone(u.Faces),
+		Actions:            slices.Clone(u.Actions),
+		Exclamations:       slices.Clone(u.Exclamations),
+		FaceWeights:        u.FaceWeights(),
+		ActionWeights:      u.ActionWeights(),
+		ExclamationWeights: u.ExclamationWeights(),
+		Rating:             u.rating,
+		Ratings:            u.Ratings(),
+		ASCIIOnly:          u.asciiOnly,
+		Moods:              u.Moods(),
+		Sentiment:          u.Sentiment(),
+		Triggers:           u.Triggers(),
+		TriggerLimit:       u.triggerLimit,
+		Accessibility:      u.accessibility,
+		FaceMarker:         u.faceMarker,
+		FaceDescriptions:   u.FaceDescriptions(),
+		Capitalization:     u.capitalization,
+		Owoify:             u.owoify,
+		Phonetics:          u.phonetics,
+		Rules:              u.Rules(),
+		Dictionary:         u.Dictionary(),
+		ProtectedWords:     u.ProtectedWords(),
+		UpstreamParity:     u.parity,
+		Personas:           u.Personas(),
+	}
+	if u.cache != nil {
+		c.Cache = u.cache.capacity
+	}
+	return c
+}
+
+// apply configures u, collecting an error for every invalid field named
+// with prefix
+func (c Config) apply(u *Uwuifier, prefix string) error {
+	var errs []error
+	check := func(field string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("config: %s%s: %w", prefix, field, err))
+		}
+	}
+
+	version := c.Version
+	if version == 0 {
+		version = DefaultAlgorithmVersion
+	}
+	check("version", u.SetAlgorithmVersion(version))
+
+	check("modifiers.words", u.SetWordsModifier(c.Modifiers.Words))
+	check("modifiers.spaces", u.SetSpacesModifier(c.Modifiers.Spaces))
+	check("modifiers.exclamations", u.SetExclamationsModifier(c.Modifiers.Exclamations))
+	check("modifiers.elongation", u.SetElongationModifier(c.Modifiers.Elongation))
+	check("modifiers.nya", u.SetNyaModifier(c.Modifiers.Nya))
+	check("stutter_counts", u.SetStutterCounts(c.StutterCounts...))
+
+	u.Faces = slices.Clone(c.Faces)
+	u.Actions = slices.Clone(c.Actions)
+	u.Exclamations = slices.Clone(c.Exclamations)
+
+	check("face_weights", u.SetFaceWeights(c.FaceWeights))
+	check("action_weights", u.SetActionWeights(c.ActionWeights))
+	check("exclamation_weights", u.SetExclamationWeights(c.ExclamationWeights))
+
+	check("rating", u.SetRating(c.Rating))
+	check("ratings", u.SetRatings(c.Ratings))
+	u.SetASCIIOnly(c.ASCIIOnly)
+	check("moods", u.SetMoods(c.Moods))
+	check("sentiment", u.SetSentiment(c.Sentiment))
+	check("triggers", u.SetTriggers(c.Triggers...))
+	check("trigger_limit", u.SetTriggerLimit(c.TriggerLimit))
+	check("accessibility", u.SetAccessibility(c.Accessibility))
+	check("face_marker", u.SetFaceMarker(c.FaceMarker))
+	check("face_descriptions", u.SetFaceDescriptions(c.FaceDescriptions))
+
+	check("capitalization", u.SetCapitalization(c.Capitalization))
+	check("owoify", u.SetOwoifyLevel(c.Owoify))
+	u.SetPhonetics(c.Phonetics)
+	check("rules", u.SetRules(c.Rules...))
+	check("dictionary", u.SetDictionary(c.Dictionary))
+	check("protected_words", u.SetProtectedWords(c.ProtectedWords...))
+
+	if c.Cache < 0 {
+		check("cache", errors.New("size must not be negative"))
+	}
+	WithCache(c.Cache)(u)
+	u.SetUpstreamParity(c.UpstreamParity)
+	check("personas", u.SetPersonas(c.Personas))
+
+	return errors.Join(errs...)
+}
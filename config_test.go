@@ -0,0 +1,152 @@
+package gouwu
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfigRoundTrip(t *testing.T) {
+	uwuifier := New(
+		WithAlgorithmVersion(AlgorithmV1),
+		WithWords(0.7),
+		WithSpaces(SpacesModifier{Faces: 0.2, Actions: 0.1, Stutters: 0.3}),
+		WithExclamations(0.5),
+		WithStutterCounts(1, 2),
+		WithExtraFaces("owo~"),
+		WithoutActions("*sweats*"),
+		WithFaceWeights(Weights{"owo~": 3}),
+		WithRating(RatingSafe),
+		WithRatings(Ratings{"owo~": RatingSuggestive}),
+		WithTriggers(Trigger{Keywords: []string{"sun"}, Action: "*basks*", Probability: 1}),
+		WithTriggerLimit(2),
+		WithRules(Rule{Pattern: "th", Replacement: "d"}),
+		WithDictionary(map[string]string{"hello": "hewwo"}),
+		WithProtectedWords("Gopher"),
+		WithCache(64),
+	)
+
+	path := filepath.Join(t.TempDir(), "uwu.json")
+	if err := uwuifier.Config().Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if !reflect.DeepEqual(config, uwuifier.Config()) {
+		t.Errorf("LoadConfig() = %+v, want %+v", config, uwuifier.Config())
+	}
+
+	loaded, err := NewFromConfig(config)
+	if err != nil {
+		t.Fatalf("NewFromConfig() error = %v", err)
+	}
+
+	input := "Hello there, the Gopher loves the sun! Do you?"
+	for range 3 {
+		if result, expected := loaded.UwuifySentence(input), uwuifier.UwuifySentence(input); result != expected {
+			t.Errorf("UwuifySentence() = %q, want %q", result, expected)
+		}
+	}
+}
+
+func TestParseConfigDefaults(t *testing.T) {
+	config, err := ParseConfig([]byte(`{"modifiers": {"spaces": {"stutters": 0.5}}, "faces": ["UwU"]}`))
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+
+	expected := DefaultConfig()
+	expected.Modifiers.Spaces.Stutters = 0.5
+	expected.Faces = []string{"UwU"}
+	if !reflect.DeepEqual(config, expected) {
+		t.Errorf("ParseConfig() = %+v, want %+v", config, expected)
+	}
+
+	uwuifier, err := NewFromConfig(config)
+	if err != nil {
+		t.Fatalf("NewFromConfig() error = %v", err)
+	}
+	if uwuifier.WordsModifier() != DefaultWords || uwuifier.SpacesModifier().Faces != DefaultSpaces.Faces {
+		t.Error("fields missing from the file should keep their defaults")
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"syntax", `{"faces": [}`, "invalid character"},
+		{"unknown field", `{"facez": []}`, `unknown field "facez"`},
+		{"unknown nested field", `{"modifiers": {"spaces": {"face": 1}}}`, `unknown field "face"`},
+		{"wrong type", `{"modifiers": {"words": "high"}}`, "cannot unmarshal"},
+		{"words", `{"modifiers": {"words": 2}}`, "modifiers.words"},
+		{"spaces", `{"modifiers": {"spaces": {"faces": 0.9, "actions": 0.9}}}`, "modifiers.spaces"},
+		{"version", `{"version": 99}`, "version"},
+		{"weights", `{"face_weights": {"UwU": -1}}`, "face_weights"},
+		{"rules", `{"rules": [{"pattern": "("}]}`, "rules"},
+		{"dictionary", `{"dictionary": {"two words": "x"}}`, "dictionary"},
+		{"protected words", `{"protected_words": [""]}`, "protected_words"},
+		{"cache", `{"cache": -1}`, "cache"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(tc.input))
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("ParseConfig(%s) error = %v, want it to mention %q", tc.input, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestConfigValidateReportsEveryField(t *testing.T) {
+	config := DefaultConfig()
+	config.Modifiers.Words = -1
+	config.Modifiers.Exclamations = 2
+
+	err := config.Validate()
+	if err == nil {
+		t.Fatal("Validate() should have errored")
+	}
+	for _, field := range []string{"modifiers.words", "modifiers.exclamations"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("Validate() error = %v, want it to mention %s", err, field)
+		}
+	}
+
+	if _, err := NewFromConfig(config); err == nil {
+		t.Error("NewFromConfig() should have errored")
+	}
+}
+
+func TestConfigUnmarshalIsStrict(t *testing.T) {
+	var config Config
+	if err := json.Unmarshal([]byte(`{"stutters": 1}`), &config); err == nil {
+		t.Error("json.Unmarshal() should reject unknown fields")
+	}
+}
+
+func TestLoadConfigMissingFile(t *testing.T) {
+	_, err := LoadConfig(filepath.Join(t.TempDir(), "missing.json"))
+	if !os.IsNotExist(err) {
+		t.Errorf("LoadConfig() error = %v, want a not exist error", err)
+	}
+}
+
+func TestNewFromConfigOptions(t *testing.T) {
+	uwuifier, err := NewFromConfig(DefaultConfig(), WithWords(0.25))
+	if err != nil {
+		t.Fatalf("NewFromConfig() error = %v", err)
+	}
+	if uwuifier.WordsModifier() != 0.25 {
+		t.Errorf("WordsModifier() = %v, want options applied after the config", uwuifier.WordsModifier())
+	}
+}
@@ -0,0 +1,186 @@
+package gouwu
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WithDictionary sets whole-word replacements, see SetDictionary
+func WithDictionary(dictionary map[string]string) Option {
+	return func(u *Uwuifier) {
+		u.SetDictionary(dictionary)
+	}
+}
+
+// WithProtectedWords sets the words left untouched, see SetProtectedWords
+func WithProtectedWords(words ...string) Option {
+	return func(u *Uwuifier) {
+		u.SetProtectedWords(words...)
+	}
+}
+
+// Dictionary returns a copy of the whole-word replacements, keyed by the
+// lowercase words they replace
+func (u *Uwuifier) Dictionary() map[string]string { return maps.Clone(u.dictionary) }
+
+// ProtectedWords returns the protected words in lowercase, sorted
+func (u *Uwuifier) ProtectedWords() []string {
+	return slices.Sorted(maps.Keys(u.protected))
+}
+
+// SetDictionary replaces the whole-word replacements. Words match ignoring
+// case and surrounding punctuation, so "hello" also replaces "Hello," and
+// the replacement follows the case of the word it replaces. A replaced word
+// skips the replacement rules, and dictionary entries apply whenever the
+// words stage runs regardless of the words modifier.
+func (u *Uwuifier) SetDictionary(dictionary map[string]string) error {
+	folded := make(map[string]string, len(dictionary))
+	for word, replacement := range dictionary {
+		if err := checkWord("dictionary", word); err != nil {
+			return err
+		}
+		folded[strings.ToLower(word)] = replacement
+	}
+	if len(folded) == 0 {
+		folded = nil
+	}
+
+	u.dictionary = folded
+	u.changed()
+	return nil
+}
+
+// SetProtectedWords replaces the words left untouched. Like dictionary words
+// they match ignoring case and surrounding punctuation. Protected words skip
+// the replacement rules, the dictionary, exclamations, stutters and
+// capitalization changes; faces and actions may still follow them.
+func (u *Uwuifier) SetProtectedWords(words ...string) error {
+	protected := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		if err := checkWord("protected", word); err != nil {
+			return err
+		}
+		protected[strings.ToLower(word)] = struct{}{}
+	}
+	if len(protected) == 0 {
+		protected = nil
+	}
+
+	u.protected = protected
+	u.changed()
+	return nil
+}
+
+// checkWord reports whether word can ever match a token
+func checkWord(kind, word string) error {
+	switch {
+	case word == "":
+		return errors.New(kind + " words must not be empty")
+	case !utf8.ValidString(word):
+		return fmt.Errorf("%s word %q is not valid UTF-8", kind, word)
+	case strings.ContainsFunc(word, unicode.IsSpace):
+		return fmt.Errorf("%s word %q must not contain spaces", kind, word)
+	}
+
+	if start, end := wordCore([]byte(word)); start != 0 || end != len(word) {
+		return fmt.Errorf("%s word %q must not start or end with punctuation", kind, word)
+	}
+	return nil
+}
+
+// wordCore returns the bounds of word without its leading and trailing
+// punctuation
+func wordCore(word []byte) (int, int) {
+	start, end := 0, len(word)
+	for start < end {
+		r, size := utf8.DecodeRune(word[start:end])
+		if !unicode.IsPunct(r) {
+			break
+		}
+		start += size
+	}
+	for end > start {
+		r, size := utf8.DecodeLastRune(word[start:end])
+		if !unicode.IsPunct(r) {
+			break
+		}
+		end -= size
+	}
+	return start, end
+}
+
+// appendFold appends the lowercase form of word to dst, the way
+// strings.ToLower folds dictionary and protected words
+func appendFold(dst, word []byte) []byte {
+	for i := 0; i < len(word); {
+		r, size := utf8.DecodeRune(word[i:])
+		i += size
+		dst = utf8.AppendRune(dst, unicode.ToLower(r))
+	}
+	return dst
+}
+
+// isProtected reports whether sc.word is a protected word
+func (u *Uwuifier) isProtected(sc *scratch) bool {
+	if len(u.protected) == 0 {
+		return false
+	}
+
+	start, end := wordCore(sc.word)
+	if start == end {
+		return false
+	}
+
+	sc.fold = appendFold(sc.fold[:0], sc.word[start:end])
+	_, ok := u.protected[string(sc.fold)]
+	return ok
+}
+
+// replaceFromDictionary replaces sc.word if it is a dictionary word and
+// reports whether it did
+func (u *Uwuifier) replaceFromDictionary(sc *scratch) bool {
+	if len(u.dictionary) == 0 {
+		return false
+	}
+
+	start, end := wordCore(sc.word)
+	if start == end {
+		return false
+	}
+
+	sc.fold = appendFold(sc.fold[:0], sc.word[start:end])
+	replacement, ok := u.dictionary[string(sc.fold)]
+	if !ok || u.asciiOnly && !isASCII(replacement) {
+		return false
+	}
+
+	sc.spare = append(sc.spare[:0], sc.word[:start]...)
+	sc.spare = appendMatchingCase(sc.spare, replacement, sc.word[start:end])
+	sc.spare = append(sc.spare, sc.word[end:]...)
+	sc.word, sc.spare = sc.spare, sc.word
+	return true
+}
+
+// appendMatchingCase appends replacement to dst in the case of word: all
+// caps if word is, capitalized if word starts with a capital, else as is
+func appendMatchingCase(dst []byte, replacement string, word []byte) []byte {
+	first, _ := utf8.DecodeRune(word)
+	switch {
+	case getCapitalPercentage(word) == 1 && utf8.RuneCount(word) > 1:
+		return append(dst, strings.ToUpper(replacement)...)
+	case unicode.IsUpper(first):
+		r, size := utf8.DecodeRuneInString(replacement)
+		if r == utf8.RuneError {
+			return append(dst, replacement...)
+		}
+		dst = utf8.AppendRune(dst, unicode.ToUpper(r))
+		return append(dst, replacement[size:]...)
+	default:
+		return append(dst, replacement...)
+	}
+}
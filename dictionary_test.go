@@ -0,0 +1,84 @@
+package gouwu
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDictionary(t *testing.T) {
+	uwuifier := New(
+		WithWords(1),
+		WithDictionary(map[string]string{"hello": "hewwo", "Friend": "fwen"}),
+	)
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"hello", "hewwo"},
+		{"Hello,", "Hewwo,"},
+		{"HELLO!", "HEWWO!"},
+		{"(friend)", "(fwen)"},
+		{"hellos", "hewwos"},
+		{"hi friend", "hi fwen"},
+	}
+
+	for _, tc := range testCases {
+		if result := uwuifier.UwuifyWords(tc.input); result != tc.expected {
+			t.Errorf("UwuifyWords(%q) = %q, want %q", tc.input, result, tc.expected)
+		}
+	}
+
+	// Dictionary words are replaced even when rules never apply
+	uwuifier.SetWordsModifier(0)
+	if result := uwuifier.UwuifyWords("Hello there"); result != "Hewwo there" {
+		t.Errorf("UwuifyWords() = %q, want %q", result, "Hewwo there")
+	}
+
+	if dictionary := uwuifier.Dictionary(); dictionary["friend"] != "fwen" {
+		t.Errorf("Dictionary() = %v, want lowercase keys", dictionary)
+	}
+}
+
+func TestProtectedWords(t *testing.T) {
+	uwuifier := New(
+		WithWords(1),
+		WithExclamations(1),
+		WithSpaces(SpacesModifier{Stutters: 1}),
+		WithDictionary(map[string]string{"gopher": "gophew"}),
+		WithProtectedWords("Gopher", "README"),
+	)
+
+	for _, input := range []string{"Gopher!", "gopher", "readme.", "(README)"} {
+		if result := uwuifier.UwuifySentence(input); result != input {
+			t.Errorf("UwuifySentence(%q) = %q, want it untouched", input, result)
+		}
+	}
+
+	if words := uwuifier.ProtectedWords(); !slices.Equal(words, []string{"gopher", "readme"}) {
+		t.Errorf("ProtectedWords() = %v", words)
+	}
+}
+
+func TestProtectedWordsKeepCapitals(t *testing.T) {
+	uwuifier := New(WithSpaces(SpacesModifier{Faces: 1}), WithFaces("^w^"))
+	if result := uwuifier.UwuifySpaces("Gopher"); result != "gopher ^w^" {
+		t.Errorf("UwuifySpaces(%q) = %q, want %q", "Gopher", result, "gopher ^w^")
+	}
+
+	uwuifier.SetProtectedWords("gopher")
+	if result := uwuifier.UwuifySpaces("Gopher"); result != "Gopher ^w^" {
+		t.Errorf("UwuifySpaces(%q) = %q, want %q", "Gopher", result, "Gopher ^w^")
+	}
+}
+
+func TestWordValidation(t *testing.T) {
+	for _, word := range []string{"", "two words", "hello!", "\xff"} {
+		if err := New().SetProtectedWords(word); err == nil {
+			t.Errorf("SetProtectedWords(%q) should have errored", word)
+		}
+		if err := New().SetDictionary(map[string]string{word: "x"}); err == nil {
+			t.Errorf("SetDictionary(%q) should have errored", word)
+		}
+	}
+}
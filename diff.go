@@ -0,0 +1,205 @@
+package gouwu
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Change is a single difference between two configurations. Field is named
+// after the JSON configuration, like "modifiers.spaces.stutters", "faces" or
+// `face_weights["UwU"]`. From is empty when something was added and To is
+// empty when something was removed.
+type Change struct {
+	Field string
+	From  string
+	To    string
+}
+
+// String formats the change as "field: from -> to", "field: + to" or
+// "field: - from"
+func (c Change) String() string {
+	switch {
+	case c.From == "":
+		return c.Field + ": + " + c.To
+	case c.To == "":
+		return c.Field + ": - " + c.From
+	}
+	return c.Field + ": " + c.From + " -> " + c.To
+}
+
+// DiffConfig lists the changes that turn from into to, ignoring profiles.
+// Items added to or removed from a list are listed one by one; a list that
+// was only reordered is listed as a whole.
+func DiffConfig(from, to Config) []Change {
+	var changes []Change
+
+	diffValue(&changes, "version", from.Version, to.Version)
+	diffValue(&changes, "modifiers.words", from.Modifiers.Words, to.Modifiers.Words)
+	diffValue(&changes, "modifiers.spaces.faces", from.Modifiers.Spaces.Faces, to.Modifiers.Spaces.Faces)
+	diffValue(&changes, "modifiers.spaces.actions", from.Modifiers.Spaces.Actions, to.Modifiers.Spaces.Actions)
+	diffValue(&changes, "modifiers.spaces.stutters", from.Modifiers.Spaces.Stutters, to.Modifiers.Spaces.Stutters)
+	diffValue(&changes, "modifiers.exclamations", from.Modifiers.Exclamations, to.Modifiers.Exclamations)
+	diffValue(&changes, "modifiers.elongation.vowels", from.Modifiers.Elongation.Vowels, to.Modifiers.Elongation.Vowels)
+	diffValue(&changes, "modifiers.elongation.tildes", from.Modifiers.Elongation.Tildes, to.Modifiers.Elongation.Tildes)
+	diffValue(&changes, "modifiers.nya.endings", from.Modifiers.Nya.Endings, to.Modifiers.Nya.Endings)
+	diffValue(&changes, "modifiers.nya.emphasis", from.Modifiers.Nya.Emphasis, to.Modifiers.Nya.Emphasis)
+	diffValue(&changes, "modifiers.nya.purrs", from.Modifiers.Nya.Purrs, to.Modifiers.Nya.Purrs)
+	diffValue(&changes, "modifiers.nya.mews", from.Modifiers.Nya.Mews, to.Modifiers.Nya.Mews)
+	diffValue(&changes, "stutter_counts", fmt.Sprint(from.StutterCounts), fmt.Sprint(to.StutterCounts))
+
+	diffList(&changes, "faces", from.Faces, to.Faces)
+	diffList(&changes, "actions", from.Actions, to.Actions)
+	diffList(&changes, "exclamations", from.Exclamations, to.Exclamations)
+
+	diffMap(&changes, "face_weights", from.FaceWeights, to.FaceWeights, formatFloat)
+	diffMap(&changes, "action_weights", from.ActionWeights, to.ActionWeights, formatFloat)
+	diffMap(&changes, "exclamation_weights", from.ExclamationWeights, to.ExclamationWeights, formatFloat)
+
+	diffValue(&changes, "rating", from.Rating, to.Rating)
+	diffMap(&changes, "ratings", from.Ratings, to.Ratings, Rating.String)
+	diffValue(&changes, "ascii_only", from.ASCIIOnly, to.ASCIIOnly)
+	diffMap(&changes, "moods", joinMoods(from.Moods), joinMoods(to.Moods), strconv.Quote)
+	diffMap(&changes, "sentiment", from.Sentiment, to.Sentiment, func(m Mood) string { return string(m) })
+
+	diffList(&changes, "triggers", formatTriggers(from.Triggers), formatTriggers(to.Triggers))
+	diffValue(&changes, "trigger_limit", from.TriggerLimit, to.TriggerLimit)
+
+	diffValue(&changes, "accessibility", from.Accessibility, to.Accessibility)
+	diffValue(&changes, "face_marker", from.FaceMarker, to.FaceMarker)
+	diffMap(&changes, "face_descriptions", from.FaceDescriptions, to.FaceDescriptions, strconv.Quote)
+
+	diffValue(&changes, "capitalization", from.Capitalization, to.Capitalization)
+	diffValue(&changes, "owoify", from.Owoify, to.Owoify)
+	diffValue(&changes, "phonetics.initial_th", from.Phonetics.InitialTh, to.Phonetics.InitialTh)
+	diffValue(&changes, "phonetics.medial_th", from.Phonetics.MedialTh, to.Phonetics.MedialTh)
+	diffValue(&changes, "phonetics.ing", from.Phonetics.Ing, to.Phonetics.Ing)
+	diffValue(&changes, "phonetics.diminutives", from.Phonetics.Diminutives, to.Phonetics.Diminutives)
+	diffValue(&changes, "phonetics.you", from.Phonetics.You, to.Phonetics.You)
+	diffList(&changes, "rules", formatRules(from.Rules), formatRules(to.Rules))
+	diffMap(&changes, "dictionary", from.Dictionary, to.Dictionary, strconv.Quote)
+	diffList(&changes, "protected_words", from.ProtectedWords, to.ProtectedWords)
+
+	diffValue(&changes, "cache", from.Cache, to.Cache)
+	diffValue(&changes, "upstream_parity", from.UpstreamParity, to.UpstreamParity)
+	diffMap(&changes, "personas", formatPersonas(from.Personas), formatPersonas(to.Personas), strconv.Quote)
+
+	return changes
+}
+
+// diffValue records a change of a single value
+func diffValue[T comparable](changes *[]Change, field string, from, to T) {
+	if from != to {
+		*changes = append(*changes, Change{Field: field, From: fmt.Sprint(from), To: fmt.Sprint(to)})
+	}
+}
+
+// diffList records the items added to and removed from a list
+func diffList(changes *[]Change, field string, from, to []string) {
+	if slices.Equal(from, to) {
+		return
+	}
+
+	n := len(*changes)
+	for _, item := range from {
+		if !slices.Contains(to, item) {
+			*changes = append(*changes, Change{Field: field, From: strconv.Quote(item)})
+		}
+	}
+	for _, item := range to {
+		if !slices.Contains(from, item) {
+			*changes = append(*changes, Change{Field: field, To: strconv.Quote(item)})
+		}
+	}
+
+	// Only the order changed
+	if len(*changes) == n {
+		*changes = append(*changes, Change{Field: field, From: formatList(from), To: formatList(to)})
+	}
+}
+
+// diffMap records the entries added to, removed from or changed in a map
+func diffMap[V comparable](changes *[]Change, field string, from, to map[string]V, format func(V) string) {
+	keys := slices.Collect(maps.Keys(from))
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		change := Change{Field: fmt.Sprintf("%s[%q]", field, key)}
+		before, inFrom := from[key]
+		after, inTo := to[key]
+		if inFrom && inTo && before == after {
+			continue
+		}
+		if inFrom {
+			change.From = format(before)
+		}
+		if inTo {
+			change.To = format(after)
+		}
+		*changes = append(*changes, change)
+	}
+}
+
+// formatFloat formats a weight
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// formatList formats a list as a bracketed list of quoted items
+func formatList(list []string) string {
+	quoted := make([]string, len(list))
+	for i, item := range list {
+		quoted[i] = strconv.Quote(item)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// joinMoods joins the moods of every expression with commas for diffing
+func joinMoods(moods Moods) map[string]string {
+	joined := make(map[string]string, len(moods))
+	for expression, list := range moods {
+		names := make([]string, len(list))
+		for i, mood := range list {
+			names[i] = string(mood)
+		}
+		joined[expression] = strings.Join(names, ",")
+	}
+	return joined
+}
+
+// formatTriggers formats triggers as "keywords => action (probability)" for
+// diffing
+func formatTriggers(triggers []Trigger) []string {
+	formatted := make([]string, len(triggers))
+	for i, trigger := range triggers {
+		formatted[i] = fmt.Sprintf("%s => %s (%s)", strings.Join(trigger.Keywords, ","), trigger.Action, formatFloat(trigger.Probability))
+	}
+	return formatted
+}
+
+// formatRules formats rules as "pattern => replacement" for diffing
+func formatRules(rules []Rule) []string {
+	formatted := make([]string, len(rules))
+	for i, rule := range rules {
+		formatted[i] = rule.Pattern + " => " + rule.Replacement
+	}
+	return formatted
+}
+
+// formatPersonas formats each persona as its JSON configuration
+func formatPersonas(personas map[string]Persona) map[string]string {
+	formatted := make(map[string]string, len(personas))
+	for name, persona := range personas {
+		data, _ := json.Marshal(persona)
+		formatted[name] = string(data)
+	}
+	return formatted
+}
@@ -38,4 +38,13 @@
 //   - UwuifySpaces: Adds faces, actions, or stutters between words
 //   - UwuifyExclamations: Replaces exclamations with more expressive variants
 //   - UwuifySentence: Combines all transformations with URL preservation
+//
+// Output is pinned by an AlgorithmVersion, so text uwuified under one version
+// can always be reproduced with WithAlgorithmVersion, even after the defaults
+// move on to a newer version.
+//
+// AppendUwuify performs the same transformation as UwuifySentence on byte
+// slices, appending to a caller supplied buffer without allocating:
+//
+//	buf = uwuifier.AppendUwuify(buf[:0], message)
 package gouwu
@@ -0,0 +1,113 @@
+package gouwu
+
+import "errors"
+
+// ElongationModifier defines probabilities for the elongation stage
+type ElongationModifier struct {
+	// Vowels is the probability that a word ending in a vowel has it
+	// stretched, like "sooo"
+	Vowels float64 `json:"vowels"`
+
+	// Tildes is the probability that the last word of a sentence gets a
+	// tilde, like "cute~"
+	Tildes float64 `json:"tildes"`
+}
+
+// WithElongation sets the elongation probabilities
+func WithElongation(elongation ElongationModifier) Option {
+	return func(u *Uwuifier) {
+		u.SetElongationModifier(elongation)
+	}
+}
+
+// ElongationModifier returns the elongation probabilities
+func (u *Uwuifier) ElongationModifier() ElongationModifier { return u.elongationModifier }
+
+// SetElongationModifier sets the elongation probabilities. Both are 0 by
+// default, which turns the stage off.
+func (u *Uwuifier) SetElongationModifier(value ElongationModifier) error {
+	if value.Vowels < 0 || value.Vowels > 1 || value.Tildes < 0 || value.Tildes > 1 {
+		return errors.New("elongationModifier values must be between 0 and 1")
+	}
+	u.elongationModifier = value
+	u.changed()
+	return nil
+}
+
+// UwuifyElongation stretches final vowels and adds tildes to sentence ends
+func (u *Uwuifier) UwuifyElongation(sentence string) string {
+	return u.uwuifyString(sentence, stageElongation)
+}
+
+// elongateWord stretches the final vowel of sc.word and adds a tilde if it
+// ends a sentence. last is whether the word ends the text.
+func (u *Uwuifier) elongateWord(sc *scratch, last bool) {
+	if u.elongationModifier == (ElongationModifier{}) || isAt(sc.word) || isURI(sc.word) {
+		return
+	}
+
+	start, end := wordCore(sc.word)
+	if start == end {
+		return
+	}
+
+	r := u.randFor(sc.word)
+	stretch := r.float() <= u.elongationModifier.Vowels && stretchable(sc.word[start:end])
+	tilde := r.float() <= u.elongationModifier.Tildes && (last || endsSentence(sc.word[end:]))
+	if !stretch && !tilde {
+		return
+	}
+
+	sc.spare = append(sc.spare[:0], sc.word[:end]...)
+	if stretch {
+		for range 1 + r.intn(3) {
+			sc.spare = append(sc.spare, sc.word[end-1])
+		}
+	}
+
+	rest := sc.word[end:]
+	if tilde {
+		sc.spare = append(sc.spare, '~')
+		// The tilde takes the place of a full stop
+		if len(rest) == 1 && rest[0] == '.' {
+			rest = rest[1:]
+		}
+	}
+	sc.spare = append(sc.spare, rest...)
+	sc.word, sc.spare = sc.spare, sc.word
+}
+
+// stretchable reports whether core ends in a vowel worth stretching: a, i,
+// o, u or y, or an e that is not silent like in "cute"
+func stretchable(core []byte) bool {
+	if len(core) < 2 {
+		return false
+	}
+
+	switch last := core[len(core)-1] | 0x20; last {
+	case 'a', 'i', 'o', 'u', 'y':
+		return true
+	case 'e':
+		return len(core) == 2 || isVowel(core[len(core)-2])
+	}
+	return false
+}
+
+// isVowel reports whether c is an ASCII vowel
+func isVowel(c byte) bool {
+	switch c | 0x20 {
+	case 'a', 'e', 'i', 'o', 'u':
+		return true
+	}
+	return false
+}
+
+// endsSentence reports whether the punctuation after a word ends a sentence
+func endsSentence(rest []byte) bool {
+	for _, c := range rest {
+		if c == '.' || c == '!' || c == '?' {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package gouwu
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestElongation(t *testing.T) {
+	testCases := []struct {
+		name       string
+		elongation ElongationModifier
+		input      string
+		expected   string
+	}{
+		{"stretch", ElongationModifier{Vowels: 1}, "hi so cute", `^hii{1,3} sooo{0,2} cute$`},
+		{"stretch y and e", ElongationModifier{Vowels: 1}, "hey me the", `^heyyy{0,2} meee{0,2} the$`},
+		{"stretch keeps case", ElongationModifier{Vowels: 1}, "HI!", `^HIII{0,2}!$`},
+		{"tilde at the end", ElongationModifier{Tildes: 1}, "so cute", `^so cute~$`},
+		{"tilde replaces full stop", ElongationModifier{Tildes: 1}, "so cute. yes", `^so cute~ yes~$`},
+		{"tilde before exclamations", ElongationModifier{Tildes: 1}, "what?! hi!", `^what~\?! hi~!$`},
+		{"tilde before ellipsis", ElongationModifier{Tildes: 1}, "so...", `^so~\.\.\.$`},
+		{"both", ElongationModifier{Vowels: 1, Tildes: 1}, "hi.", `^hii{1,3}~$`},
+		{"skips mentions and links", ElongationModifier{Vowels: 1, Tildes: 1}, "@yo https://go.dev", `^@yo https://go.dev$`},
+		{"skips punctuation", ElongationModifier{Vowels: 1, Tildes: 1}, "hi !!!", `^hii{1,3} !!!$`},
+		{"off", ElongationModifier{}, "hi so cute.", `^hi so cute\.$`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uwuifier := New(WithElongation(tc.elongation))
+			result := uwuifier.UwuifyElongation(tc.input)
+			if !regexp.MustCompile(tc.expected).MatchString(result) {
+				t.Errorf("UwuifyElongation(%q) = %q, want %s", tc.input, result, tc.expected)
+			}
+			if again := uwuifier.UwuifyElongation(tc.input); again != result {
+				t.Errorf("UwuifyElongation(%q) = %q, then %q", tc.input, result, again)
+			}
+		})
+	}
+}
+
+func TestElongationProtectedWords(t *testing.T) {
+	uwuifier := New(
+		WithElongation(ElongationModifier{Vowels: 1, Tildes: 1}),
+		WithProtectedWords("Go", "Kubernetes"),
+	)
+
+	if result := uwuifier.UwuifyElongation("Go. Kubernetes"); result != "Go. Kubernetes" {
+		t.Errorf("UwuifyElongation() = %q, want protected words untouched", result)
+	}
+}
+
+func TestElongationInSentences(t *testing.T) {
+	input := "hi there. so cute! do you want to go to the sea"
+	elongation := WithElongation(ElongationModifier{Vowels: 0.5, Tildes: 1})
+
+	plain := New(elongation)
+	seeded := New(elongation, WithRandomSource(PerWordSource()))
+	cached := New(elongation, WithCache(64))
+
+	for range 3 {
+		expected := plain.UwuifySentence(input)
+		if result := cached.UwuifySentence(input); result != expected {
+			t.Errorf("cached UwuifySentence(%q) = %q, want %q", input, result, expected)
+		}
+		if result := cached.UwuifySentence("sea " + input); result != plain.UwuifySentence("sea "+input) {
+			t.Errorf("cached UwuifySentence() = %q, want %q", result, plain.UwuifySentence("sea "+input))
+		}
+		if result := seeded.UwuifySentence(input); result != expected {
+			t.Errorf("seeded UwuifySentence(%q) = %q, want %q", input, result, expected)
+		}
+	}
+}
+
+func TestStretchable(t *testing.T) {
+	testCases := map[string]bool{
+		"hi": true, "so": true, "day": true, "you": true, "me": true, "see": true,
+		"tea": true, "cute": false, "the": false, "cat": false, "a": false, "I": false,
+	}
+
+	for core, expected := range testCases {
+		if result := stretchable([]byte(core)); result != expected {
+			t.Errorf("stretchable(%q) = %v, want %v", core, result, expected)
+		}
+	}
+}
+
+func TestSetElongationModifier(t *testing.T) {
+	for _, value := range []ElongationModifier{{Vowels: -0.1}, {Tildes: 1.5}} {
+		if err := New().SetElongationModifier(value); err == nil {
+			t.Errorf("SetElongationModifier(%+v) should have errored", value)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package gouwu
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ConfigFromEnv builds a configuration from GOUWU_* environment variables,
+// layering, from lowest to highest precedence:
+//
+//  1. the preset named by GOUWU_PRESET, or "default"
+//  2. the JSON configuration file named by GOUWU_CONFIG
+//  3. the profile of that configuration named by GOUWU_PROFILE
+//  4. the expression pack named by GOUWU_PACK
+//  5. the individual variables below
+//
+// The individual variables are:
+//
+//	GOUWU_VERSION           algorithm version
+//	GOUWU_WORDS             words modifier
+//	GOUWU_FACES_PROB        faces probability
+//	GOUWU_ACTIONS_PROB      actions probability
+//	GOUWU_STUTTERS_PROB     stutters probability
+//	GOUWU_EXCLAMATIONS      exclamations modifier
+//	GOUWU_VOWELS_PROB       vowel stretching probability
+//	GOUWU_TILDES_PROB       sentence end tilde probability
+//	GOUWU_FACES             comma separated faces
+//	GOUWU_ACTIONS           comma separated actions
+//	GOUWU_EXCLAMATION_LIST  comma separated exclamations
+//	GOUWU_PROTECTED_WORDS   comma separated protected words
+//	GOUWU_RATING            highest rating, safe or suggestive
+//	GOUWU_ASCII_ONLY        true to only insert ASCII
+//	GOUWU_SENTIMENT         true to detect moods with DefaultLexicon
+//	GOUWU_TRIGGER_LIMIT     triggered actions per sentence
+//	GOUWU_ACCESSIBILITY     omit, describe or mark faces for screen readers
+//	GOUWU_CAPITALIZATION    legacy, keep, lowercase or sentence
+//	GOUWU_OWOIFY            owo, uwu or uvu to use the owoify mappings
+//	GOUWU_CACHE             word cache size
+//	GOUWU_UPSTREAM_PARITY   true or false
+//
+// Empty variables are ignored. Every malformed variable is reported.
+func ConfigFromEnv() (Config, error) {
+	return configFromEnv(os.LookupEnv)
+}
+
+// NewFromEnv creates an Uwuifier configured by ConfigFromEnv, applying opts
+// after it
+func NewFromEnv(opts ...Option) (*Uwuifier, error) {
+	c, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewFromConfig(c, opts...)
+}
+
+// configFromEnv is ConfigFromEnv reading variables with lookup
+func configFromEnv(lookup func(string) (string, bool)) (Config, error) {
+	env := envReader{lookup: lookup}
+
+	preset := "default"
+	if name, ok := env.get("GOUWU_PRESET"); ok {
+		preset = name
+	}
+	c, err := PresetConfig(preset)
+	if err != nil {
+		return Config{}, fmt.Errorf("GOUWU_PRESET: %w", err)
+	}
+
+	if path, ok := env.get("GOUWU_CONFIG"); ok {
+		if c, err = loadConfig(c, path); err != nil {
+			return Config{}, fmt.Errorf("GOUWU_CONFIG: %w", err)
+		}
+	}
+	if profile, ok := env.get("GOUWU_PROFILE"); ok {
+		if c, err = c.ResolveProfile(profile); err != nil {
+			return Config{}, fmt.Errorf("GOUWU_PROFILE: %w", err)
+		}
+	}
+
+	if name, ok := env.get("GOUWU_PACK"); ok {
+		pack, err := LookupPack(name)
+		if err != nil {
+			return Config{}, fmt.Errorf("GOUWU_PACK: %w", err)
+		}
+		c.Faces, c.Actions, c.Exclamations = pack.Faces, pack.Actions, pack.Exclamations
+	}
+
+	var version int
+	if env.integer("GOUWU_VERSION", &version) {
+		c.Version = AlgorithmVersion(version)
+	}
+	env.probability("GOUWU_WORDS", &c.Modifiers.Words)
+	env.probability("GOUWU_FACES_PROB", &c.Modifiers.Spaces.Faces)
+	env.probability("GOUWU_ACTIONS_PROB", &c.Modifiers.Spaces.Actions)
+	env.probability("GOUWU_STUTTERS_PROB", &c.Modifiers.Spaces.Stutters)
+	env.probability("GOUWU_EXCLAMATIONS", &c.Modifiers.Exclamations)
+	env.probability("GOUWU_VOWELS_PROB", &c.Modifiers.Elongation.Vowels)
+	env.probability("GOUWU_TILDES_PROB", &c.Modifiers.Elongation.Tildes)
+	env.list("GOUWU_FACES", &c.Faces)
+	env.list("GOUWU_ACTIONS", &c.Actions)
+	env.list("GOUWU_EXCLAMATION_LIST", &c.Exclamations)
+	env.list("GOUWU_PROTECTED_WORDS", &c.ProtectedWords)
+	env.text("GOUWU_RATING", &c.Rating)
+	env.boolean("GOUWU_ASCII_ONLY", &c.ASCIIOnly)
+	if sentiment := c.Sentiment != nil; env.boolean("GOUWU_SENTIMENT", &sentiment) {
+		c.Sentiment = nil
+		if sentiment {
+			c.Sentiment = DefaultLexicon()
+		}
+	}
+	env.integer("GOUWU_TRIGGER_LIMIT", &c.TriggerLimit)
+	env.text("GOUWU_ACCESSIBILITY", &c.Accessibility)
+	env.text("GOUWU_CAPITALIZATION", &c.Capitalization)
+	env.text("GOUWU_OWOIFY", &c.Owoify)
+	env.integer("GOUWU_CACHE", &c.Cache)
+	env.boolean("GOUWU_UPSTREAM_PARITY", &c.UpstreamParity)
+
+	if len(env.errs) > 0 {
+		return Config{}, errors.Join(env.errs...)
+	}
+	if err := c.Validate(); err != nil {
+		return Config{}, fmt.Errorf("environment: %w", err)
+	}
+	return c, nil
+}
+
+// envReader parses environment variables, collecting an error for every
+// malformed one
+type envReader struct {
+	lookup func(string) (string, bool)
+	errs   []error
+}
+
+// get returns the value of a variable that is set and not empty
+func (e *envReader) get(name string) (string, bool) {
+	value, ok := e.lookup(name)
+	value = strings.TrimSpace(value)
+	return value, ok && value != ""
+}
+
+// fail records a malformed variable
+func (e *envReader) fail(name, value, reason string) {
+	e.errs = append(e.errs, fmt.Errorf("%s=%q: %s", name, value, reason))
+}
+
+// probability parses a number between 0 and 1 into dst
+func (e *envReader) probability(name string, dst *float64) {
+	value, ok := e.get(name)
+	if !ok {
+		return
+	}
+
+	probability, err := strconv.ParseFloat(value, 64)
+	switch {
+	case err != nil:
+		e.fail(name, value, "not a number")
+	case probability < 0 || probability > 1:
+		e.fail(name, value, "must be between 0 and 1")
+	default:
+		*dst = probability
+	}
+}
+
+// integer parses an integer into dst and reports whether it did
+func (e *envReader) integer(name string, dst *int) bool {
+	value, ok := e.get(name)
+	if !ok {
+		return false
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		e.fail(name, value, "not an integer")
+		return false
+	}
+	*dst = n
+	return true
+}
+
+// boolean parses a boolean into dst and reports whether it did
+func (e *envReader) boolean(name string, dst *bool) bool {
+	value, ok := e.get(name)
+	if !ok {
+		return false
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		e.fail(name, value, "not true or false")
+		return false
+	}
+	*dst = b
+	return true
+}
+
+// text parses a value with its UnmarshalText method into dst
+func (e *envReader) text(name string, dst encoding.TextUnmarshaler) {
+	value, ok := e.get(name)
+	if !ok {
+		return
+	}
+
+	if err := dst.UnmarshalText([]byte(value)); err != nil {
+		e.fail(name, value, err.Error())
+	}
+}
+
+// list parses a comma separated list into dst, dropping empty items
+func (e *envReader) list(name string, dst *[]string) {
+	value, ok := e.get(name)
+	if !ok {
+		return
+	}
+
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	*dst = items
+}
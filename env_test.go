@@ -0,0 +1,184 @@
+package gouwu
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// envLookup looks variables up in vars instead of the environment
+func envLookup(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := vars[name]
+		return value, ok
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	config, err := configFromEnv(envLookup(map[string]string{
+		"GOUWU_VERSION":         "1",
+		"GOUWU_WORDS":           "0.5",
+		"GOUWU_FACES_PROB":      "0.1",
+		"GOUWU_ACTIONS_PROB":    " 0.2 ",
+		"GOUWU_STUTTERS_PROB":   "0.3",
+		"GOUWU_EXCLAMATIONS":    "0",
+		"GOUWU_FACES":           "UwU, OwO ,,:3",
+		"GOUWU_ACTIONS":         "",
+		"GOUWU_PROTECTED_WORDS": "gopher",
+		"GOUWU_ACCESSIBILITY":   "describe",
+		"GOUWU_CACHE":           "128",
+		"GOUWU_UPSTREAM_PARITY": "true",
+	}))
+	if err != nil {
+		t.Fatalf("configFromEnv() error = %v", err)
+	}
+
+	expected := DefaultConfig()
+	expected.Version = AlgorithmV1
+	expected.Modifiers = Modifiers{
+		Words:        0.5,
+		Spaces:       SpacesModifier{Faces: 0.1, Actions: 0.2, Stutters: 0.3},
+		Exclamations: 0,
+	}
+	expected.Faces = []string{"UwU", "OwO", ":3"}
+	expected.ProtectedWords = []string{"gopher"}
+	expected.Accessibility = AccessibilityDescribe
+	expected.Cache = 128
+	expected.UpstreamParity = true
+
+	if !reflect.DeepEqual(config, expected) {
+		t.Errorf("configFromEnv() = %+v, want %+v", config, expected)
+	}
+}
+
+func TestConfigFromEnvLayers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "uwu.json")
+	if err := os.WriteFile(path, []byte(`{"modifiers": {"words": 0.2, "spaces": {"faces": 0.01}}, "faces": ["^w^"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := configFromEnv(envLookup(map[string]string{
+		"GOUWU_PRESET": "chaotic",
+		"GOUWU_CONFIG": path,
+		"GOUWU_WORDS":  "0.7",
+	}))
+	if err != nil {
+		t.Fatalf("configFromEnv() error = %v", err)
+	}
+
+	chaotic, _ := PresetConfig("chaotic")
+	testCases := []struct {
+		name     string
+		result   any
+		expected any
+	}{
+		{"preset", config.Modifiers.Spaces.Stutters, chaotic.Modifiers.Spaces.Stutters},
+		{"file over preset", config.Modifiers.Spaces.Faces, 0.01},
+		{"file over preset", config.Faces, []string{"^w^"}},
+		{"variable over file", config.Modifiers.Words, 0.7},
+	}
+
+	for _, tc := range testCases {
+		if !reflect.DeepEqual(tc.result, tc.expected) {
+			t.Errorf("%s: got %v, want %v", tc.name, tc.result, tc.expected)
+		}
+	}
+}
+
+func TestConfigFromEnvPack(t *testing.T) {
+	config, err := configFromEnv(envLookup(map[string]string{
+		"GOUWU_PACK":       "emoji",
+		"GOUWU_FACES":      "UwU",
+		"GOUWU_ASCII_ONLY": "true",
+	}))
+	if err != nil {
+		t.Fatalf("configFromEnv() error = %v", err)
+	}
+
+	emoji, _ := LookupPack("emoji")
+	expected := DefaultConfig()
+	expected.Faces = []string{"UwU"}
+	expected.Actions = emoji.Actions
+	expected.Exclamations = emoji.Exclamations
+	expected.ASCIIOnly = true
+
+	if !reflect.DeepEqual(config, expected) {
+		t.Errorf("configFromEnv() = %+v, want %+v", config, expected)
+	}
+
+	if _, err := configFromEnv(envLookup(map[string]string{"GOUWU_PACK": "nope"})); err == nil ||
+		!strings.Contains(err.Error(), "GOUWU_PACK") {
+		t.Errorf("configFromEnv() error = %v, want GOUWU_PACK error", err)
+	}
+}
+
+func TestConfigFromEnvErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		vars map[string]string
+		want []string
+	}{
+		{"preset", map[string]string{"GOUWU_PRESET": "loud"}, []string{"GOUWU_PRESET", `"loud"`, "chaotic"}},
+		{"missing file", map[string]string{"GOUWU_CONFIG": "/nonexistent/uwu.json"}, []string{"GOUWU_CONFIG"}},
+		{"not a number", map[string]string{"GOUWU_WORDS": "lots"}, []string{`GOUWU_WORDS="lots"`, "not a number"}},
+		{"out of range", map[string]string{"GOUWU_FACES_PROB": "1.5"}, []string{"GOUWU_FACES_PROB", "between 0 and 1"}},
+		{"integer", map[string]string{"GOUWU_CACHE": "big"}, []string{"GOUWU_CACHE", "not an integer"}},
+		{"boolean", map[string]string{"GOUWU_UPSTREAM_PARITY": "yes"}, []string{"GOUWU_UPSTREAM_PARITY"}},
+		{"several", map[string]string{"GOUWU_WORDS": "x", "GOUWU_EXCLAMATIONS": "-1"}, []string{"GOUWU_WORDS", "GOUWU_EXCLAMATIONS"}},
+		{"combined", map[string]string{"GOUWU_FACES_PROB": "0.6", "GOUWU_ACTIONS_PROB": "0.6"}, []string{"modifiers.spaces"}},
+		{"version", map[string]string{"GOUWU_VERSION": "9"}, []string{"version"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := configFromEnv(envLookup(tc.vars))
+			if err == nil {
+				t.Fatal("configFromEnv() should have errored")
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("configFromEnv() error = %v, want it to mention %s", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewFromEnv(t *testing.T) {
+	t.Setenv("GOUWU_PRESET", "subtle")
+	t.Setenv("GOUWU_STUTTERS_PROB", "0.25")
+
+	uwuifier, err := NewFromEnv()
+	if err != nil {
+		t.Fatalf("NewFromEnv() error = %v", err)
+	}
+
+	subtle, _ := PresetConfig("subtle")
+	if uwuifier.WordsModifier() != subtle.Modifiers.Words || uwuifier.SpacesModifier().Stutters != 0.25 {
+		t.Errorf("NewFromEnv() modifiers = %v %+v", uwuifier.WordsModifier(), uwuifier.SpacesModifier())
+	}
+}
+
+func TestConfigFromEnvProfile(t *testing.T) {
+	config, err := configFromEnv(envLookup(map[string]string{
+		"GOUWU_CONFIG":        "testdata/profiles.json",
+		"GOUWU_PROFILE":       "shy",
+		"GOUWU_STUTTERS_PROB": "0.5",
+	}))
+	if err != nil {
+		t.Fatalf("configFromEnv() error = %v", err)
+	}
+	if config.Modifiers.Spaces.Actions != 0 || config.Modifiers.Spaces.Stutters != 0.5 {
+		t.Errorf("configFromEnv() spaces = %+v, want the shy profile with stutters overridden", config.Modifiers.Spaces)
+	}
+
+	_, err = configFromEnv(envLookup(map[string]string{
+		"GOUWU_CONFIG":  "testdata/profiles.json",
+		"GOUWU_PROFILE": "grumpy",
+	}))
+	if err == nil || !strings.Contains(err.Error(), "GOUWU_PROFILE") {
+		t.Errorf("configFromEnv() error = %v, want it to mention GOUWU_PROFILE", err)
+	}
+}
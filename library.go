@@ -0,0 +1,275 @@
+package gouwu
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Expression is a face, action or exclamation of a library
+type Expression struct {
+	Text     string `json:"text"`
+	Category string `json:"category,omitempty"`
+	Rating   Rating `json:"rating,omitempty"`
+}
+
+// Library is a collection of categorized faces, actions and exclamations
+type Library struct {
+	Faces        []Expression `json:"faces,omitempty"`
+	Actions      []Expression `json:"actions,omitempty"`
+	Exclamations []Expression `json:"exclamations,omitempty"`
+}
+
+//go:embed library/kaomoji.txt
+var kaomojiLibrary []byte
+
+// defaultLibrary parses the bundled library once
+var defaultLibrary = sync.OnceValue(func() Library {
+	library, err := ParseLibrary(kaomojiLibrary)
+	if err != nil {
+		panic("gouwu: bundled library: " + err.Error())
+	}
+	return library
+})
+
+// DefaultLibrary returns a copy of the bundled kaomoji library, with over
+// two hundred expressions in categories like happy, cat or sleepy
+func DefaultLibrary() Library { return defaultLibrary().clone() }
+
+// WithLibrary replaces the faces, actions and exclamations with those of a
+// library, see SetLibrary
+func WithLibrary(library Library) Option {
+	return func(u *Uwuifier) {
+		u.SetLibrary(library)
+	}
+}
+
+// SetLibrary replaces the faces, actions and exclamations with those of a
+// library. Rated expressions are added to the ratings, and expressions in a
+// category named after a mood, like happy, are tagged with that mood unless
+// they already are.
+func (u *Uwuifier) SetLibrary(library Library) error {
+	if err := library.validate(); err != nil {
+		return err
+	}
+
+	f := u.filter()
+	ratings := mergeMap(u.ratings, library.Ratings())
+	moods := maps.Clone(u.moods)
+	for expression, tags := range library.Moods() {
+		if len(f.moodsOf(expression)) == 0 {
+			if moods == nil {
+				moods = make(Moods)
+			}
+			moods[expression] = tags
+		}
+	}
+
+	u.Faces = texts(library.Faces)
+	u.Actions = texts(library.Actions)
+	u.Exclamations = texts(library.Exclamations)
+	u.ratings = ratings
+	u.moods = moods
+	u.changed()
+	return nil
+}
+
+// ParseLibrary decodes a library in the text format of the bundled one. A
+// header like "[faces happy]" or "[actions flirty suggestive]" starts a
+// section of faces, actions or exclamations in a category, optionally with
+// a rating, and every other line is one expression. Lines starting with [
+// are always headers. Blank lines and lines starting with # are ignored.
+func ParseLibrary(data []byte) (Library, error) {
+	var library Library
+	var section *[]Expression
+	var category string
+	var rating Rating
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		if header, ok := strings.CutPrefix(text, "["); ok {
+			fields := strings.Fields(strings.TrimSuffix(header, "]"))
+			if !strings.HasSuffix(header, "]") || len(fields) == 0 || len(fields) > 3 {
+				return Library{}, fmt.Errorf("library: line %d: malformed header %q, want [kind category rating]", line, text)
+			}
+
+			switch fields[0] {
+			case "faces":
+				section = &library.Faces
+			case "actions":
+				section = &library.Actions
+			case "exclamations":
+				section = &library.Exclamations
+			default:
+				return Library{}, fmt.Errorf("library: line %d: unknown kind %q, want faces, actions or exclamations", line, fields[0])
+			}
+
+			category, rating = "", 0
+			if len(fields) > 1 {
+				category = fields[1]
+			}
+			if len(fields) > 2 {
+				if err := rating.UnmarshalText([]byte(fields[2])); err != nil {
+					return Library{}, fmt.Errorf("library: line %d: %w", line, err)
+				}
+			}
+			continue
+		}
+
+		if section == nil {
+			return Library{}, fmt.Errorf("library: line %d: expression %q before the first header", line, text)
+		}
+		*section = append(*section, Expression{Text: text, Category: category, Rating: rating})
+	}
+	if err := scanner.Err(); err != nil {
+		return Library{}, fmt.Errorf("library: %w", err)
+	}
+	return library, nil
+}
+
+// LoadLibrary reads a library file, decoding it as JSON if its name ends in
+// .json and with ParseLibrary otherwise. In JSON, expressions may be given
+// as plain strings instead of objects.
+func LoadLibrary(path string) (Library, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Library{}, err
+	}
+
+	if !strings.EqualFold(filepath.Ext(path), ".json") {
+		return ParseLibrary(data)
+	}
+
+	var library Library
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&library); err != nil {
+		return Library{}, fmt.Errorf("library: %w", err)
+	}
+	if err := library.validate(); err != nil {
+		return Library{}, err
+	}
+	return library, nil
+}
+
+// UnmarshalJSON decodes an expression from an object or a plain string
+func (e *Expression) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*e = Expression{}
+		return json.Unmarshal(data, &e.Text)
+	}
+
+	type expression Expression
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode((*expression)(e))
+}
+
+// Categories returns the sorted names of the categories in the library
+func (l Library) Categories() []string {
+	var categories []string
+	for _, list := range l.lists() {
+		for _, expression := range list {
+			if expression.Category != "" && !slices.Contains(categories, expression.Category) {
+				categories = append(categories, expression.Category)
+			}
+		}
+	}
+	slices.Sort(categories)
+	return categories
+}
+
+// Category returns the part of the library in the given categories
+func (l Library) Category(names ...string) Library {
+	keep := func(list []Expression) []Expression {
+		var kept []Expression
+		for _, expression := range list {
+			if slices.Contains(names, expression.Category) {
+				kept = append(kept, expression)
+			}
+		}
+		return kept
+	}
+	return Library{Faces: keep(l.Faces), Actions: keep(l.Actions), Exclamations: keep(l.Exclamations)}
+}
+
+// Ratings returns the ratings of the rated expressions of the library
+func (l Library) Ratings() Ratings {
+	ratings := make(Ratings)
+	for _, list := range l.lists() {
+		for _, expression := range list {
+			if expression.Rating != 0 {
+				ratings[expression.Text] = expression.Rating
+			}
+		}
+	}
+	return ratings
+}
+
+// Moods tags the faces and actions in a category named after a mood
+func (l Library) Moods() Moods {
+	moods := make(Moods)
+	for _, list := range [...][]Expression{l.Faces, l.Actions} {
+		for _, expression := range list {
+			mood := Mood(expression.Category)
+			if mood.validate() == nil && !slices.Contains(moods[expression.Text], mood) {
+				moods[expression.Text] = append(moods[expression.Text], mood)
+			}
+		}
+	}
+	return moods
+}
+
+// lists returns the faces, actions and exclamations of the library
+func (l Library) lists() [3][]Expression {
+	return [...][]Expression{l.Faces, l.Actions, l.Exclamations}
+}
+
+// clone returns a copy of the library that shares no slices with it
+func (l Library) clone() Library {
+	return Library{
+		Faces:        slices.Clone(l.Faces),
+		Actions:      slices.Clone(l.Actions),
+		Exclamations: slices.Clone(l.Exclamations),
+	}
+}
+
+// validate checks that every expression has text and a known rating
+func (l Library) validate() error {
+	for _, list := range l.lists() {
+		for _, expression := range list {
+			if expression.Text == "" {
+				return errors.New("library: expression must not be empty")
+			}
+			if err := expression.Rating.validate(); err != nil {
+				return fmt.Errorf("library: %q: %w", expression.Text, err)
+			}
+		}
+	}
+	return nil
+}
+
+// texts returns the distinct texts of list in order
+func texts(list []Expression) []string {
+	result := []string{}
+	for _, expression := range list {
+		if !slices.Contains(result, expression.Text) {
+			result = append(result, expression.Text)
+		}
+	}
+	return result
+}
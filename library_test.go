@@ -0,0 +1,169 @@
+package gouwu
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDefaultLibrary(t *testing.T) {
+	library := DefaultLibrary()
+
+	total := 0
+	for _, list := range library.lists() {
+		total += len(list)
+		if len(texts(list)) != len(list) {
+			t.Error("bundled library repeats an expression")
+		}
+	}
+	if total < 200 {
+		t.Errorf("bundled library holds %d expressions, want at least 200", total)
+	}
+
+	for _, category := range []string{"happy", "sad", "cat", "sleepy", "flirty"} {
+		if !slices.Contains(library.Categories(), category) {
+			t.Errorf("Categories() = %v, missing %q", library.Categories(), category)
+		}
+	}
+	if rating := library.Ratings()["( ͡° ͜ʖ ͡°)"]; rating != RatingSuggestive {
+		t.Errorf("Ratings() rates ( ͡° ͜ʖ ͡°) %v, want suggestive", rating)
+	}
+
+	library.Faces[0].Text = "changed"
+	if DefaultLibrary().Faces[0].Text == "changed" {
+		t.Error("DefaultLibrary() should return a copy")
+	}
+}
+
+func TestParseLibrary(t *testing.T) {
+	library, err := ParseLibrary([]byte(`# comment
+[faces happy]
+(◕‿◕)
+
+  ^w^  
+[actions flirty suggestive]
+*winks*
+[exclamations]
+!!1
+`))
+	if err != nil {
+		t.Fatalf("ParseLibrary() error = %v", err)
+	}
+
+	expected := Library{
+		Faces: []Expression{
+			{Text: "(◕‿◕)", Category: "happy"},
+			{Text: "^w^", Category: "happy"},
+		},
+		Actions:      []Expression{{Text: "*winks*", Category: "flirty", Rating: RatingSuggestive}},
+		Exclamations: []Expression{{Text: "!!1"}},
+	}
+	if !reflect.DeepEqual(library, expected) {
+		t.Errorf("ParseLibrary() = %+v, want %+v", library, expected)
+	}
+}
+
+func TestParseLibraryErrors(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"[faces happy", "line 1: malformed header"},
+		{"[]", "line 1: malformed header"},
+		{"[faces a b c]", "line 1: malformed header"},
+		{"\n[smiles]", "line 2: unknown kind"},
+		{"[faces happy spicy]", "line 1: unknown rating"},
+		{"UwU", "line 1: expression \"UwU\" before the first header"},
+	}
+
+	for _, tc := range testCases {
+		if _, err := ParseLibrary([]byte(tc.input)); err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("ParseLibrary(%q) error = %v, want %q", tc.input, err, tc.expected)
+		}
+	}
+}
+
+func TestLoadLibrary(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	library, err := LoadLibrary(write("faces.json", `{
+		"faces": ["UwU", {"text": "( ͡° ͜ʖ ͡°)", "category": "flirty", "rating": "suggestive"}],
+		"actions": [{"text": "*purrs*", "category": "cat"}]
+	}`))
+	if err != nil {
+		t.Fatalf("LoadLibrary() error = %v", err)
+	}
+	expected := Library{
+		Faces: []Expression{
+			{Text: "UwU"},
+			{Text: "( ͡° ͜ʖ ͡°)", Category: "flirty", Rating: RatingSuggestive},
+		},
+		Actions: []Expression{{Text: "*purrs*", Category: "cat"}},
+	}
+	if !reflect.DeepEqual(library, expected) {
+		t.Errorf("LoadLibrary() = %+v, want %+v", library, expected)
+	}
+
+	if library, err := LoadLibrary(write("faces.txt", "[faces]\nUwU\n")); err != nil || len(library.Faces) != 1 {
+		t.Errorf("LoadLibrary() = %+v, %v", library, err)
+	}
+
+	for _, content := range []string{
+		`{"smiles": ["UwU"]}`,
+		`{"faces": [{"text": "UwU", "mood": "happy"}]}`,
+		`{"faces": [""]}`,
+		`{"faces": [{"text": "UwU", "rating": "spicy"}]}`,
+	} {
+		if _, err := LoadLibrary(write("bad.json", content)); err == nil {
+			t.Errorf("LoadLibrary(%s) should have errored", content)
+		}
+	}
+	if _, err := LoadLibrary(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("LoadLibrary() should fail on a missing file")
+	}
+}
+
+func TestSetLibrary(t *testing.T) {
+	library := DefaultLibrary().Category("happy", "flirty")
+	uwuifier := New(WithLibrary(library))
+
+	if !reflect.DeepEqual(uwuifier.Faces, texts(library.Faces)) || !reflect.DeepEqual(uwuifier.Actions, texts(library.Actions)) {
+		t.Errorf("SetLibrary() faces = %v, actions = %v", uwuifier.Faces, uwuifier.Actions)
+	}
+	if len(uwuifier.Exclamations) != 0 {
+		t.Errorf("SetLibrary() exclamations = %v, want none", uwuifier.Exclamations)
+	}
+	if moods := uwuifier.MoodsOf("(◕‿◕)"); !reflect.DeepEqual(moods, []Mood{MoodHappy}) {
+		t.Errorf("MoodsOf(%q) = %v, want happy", "(◕‿◕)", moods)
+	}
+	if rating := uwuifier.RatingOf("( ͡° ͜ʖ ͡°)"); rating != RatingSuggestive {
+		t.Errorf("RatingOf(%q) = %v, want suggestive", "( ͡° ͜ʖ ͡°)", rating)
+	}
+
+	// Existing tags win over the categories of the library
+	if moods := New(WithLibrary(DefaultLibrary())).MoodsOf(">w<"); !reflect.DeepEqual(moods, []Mood{MoodExcited, MoodShy}) {
+		t.Errorf("MoodsOf(%q) = %v, want the built-in tags", ">w<", moods)
+	}
+
+	safe := New(WithLibrary(DefaultLibrary()), WithRating(RatingSafe), WithSpaces(SpacesModifier{Faces: 0.5, Actions: 0.5}))
+	inserts := spacedInserts(safe)
+	for expression := range library.Ratings() {
+		if inserts[expression] > 0 {
+			t.Errorf("safe uwuifier inserted %q", expression)
+		}
+	}
+
+	if err := New().SetLibrary(Library{Faces: []Expression{{Text: ""}}}); err == nil {
+		t.Error("SetLibrary() should reject empty expressions")
+	}
+}
@@ -0,0 +1,290 @@
+package gouwu
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Mood is the tone of a sentence or of a face or action
+type Mood string
+
+const (
+	MoodHappy   Mood = "happy"
+	MoodSad     Mood = "sad"
+	MoodAngry   Mood = "angry"
+	MoodShy     Mood = "shy"
+	MoodExcited Mood = "excited"
+)
+
+// moodOrder breaks ties between moods when detecting the tone of a sentence
+var moodOrder = [...]Mood{MoodAngry, MoodSad, MoodExcited, MoodHappy, MoodShy}
+
+// Moods maps faces and actions to the moods they suit
+type Moods map[string][]Mood
+
+// Lexicon maps lowercase words to the mood they express
+type Lexicon map[string]Mood
+
+// builtinMoods tags the built-in faces and actions
+var builtinMoods = Moods{
+	"(・`ω´・)":            {MoodAngry},
+	";;w;;":              {MoodSad},
+	"OwO":                {MoodExcited},
+	"UwU":                {MoodHappy},
+	">w<":                {MoodExcited, MoodShy},
+	"^w^":                {MoodHappy},
+	"ÚwÚ":                {MoodSad},
+	"^-^":                {MoodHappy},
+	":3":                 {MoodHappy},
+	"x3":                 {MoodHappy, MoodExcited},
+	"*blushes*":          {MoodShy},
+	"*whispers to self*": {MoodShy},
+	"*cries*":            {MoodSad},
+	"*screams*":          {MoodAngry, MoodExcited},
+	"*sweats*":           {MoodShy},
+	"*twerks*":           {MoodExcited},
+	"*runs away*":        {MoodShy},
+	"*screeches*":        {MoodAngry, MoodExcited},
+	"*walks away*":       {MoodAngry, MoodSad},
+	"*looks at you*":     {MoodShy},
+	"*starts twerking*":  {MoodExcited},
+	"*huggles tightly*":  {MoodHappy},
+	"*boops your nose*":  {MoodHappy},
+
+	"uwu": {MoodHappy},
+	">_<": {MoodExcited, MoodShy},
+	"T_T": {MoodSad},
+	";_;": {MoodSad},
+	"^_^": {MoodHappy},
+
+	"🥺":                   {MoodSad, MoodShy},
+	"😳":                   {MoodShy},
+	"🥰":                   {MoodHappy},
+	"😊":                   {MoodHappy},
+	"😭":                   {MoodSad},
+	"😤":                   {MoodAngry},
+	"🙈":                   {MoodShy},
+	"*blushes* 😳":         {MoodShy},
+	"*cries* 😭":           {MoodSad},
+	"*hides* 🙈":           {MoodShy},
+	"*huggles tightly* 🤗": {MoodHappy},
+	"*pouts* 😤":           {MoodAngry},
+	"*runs away* 🏃":       {MoodShy},
+}
+
+// builtinLexicon is the lexicon DefaultLexicon returns
+var builtinLexicon = Lexicon{
+	"happy": MoodHappy, "glad": MoodHappy, "love": MoodHappy, "great": MoodHappy,
+	"good": MoodHappy, "nice": MoodHappy, "thanks": MoodHappy, "thank": MoodHappy,
+	"yay": MoodHappy, "fun": MoodHappy, "enjoy": MoodHappy, "congrats": MoodHappy,
+	"got": MoodHappy, "won": MoodHappy, "proud": MoodHappy, "hug": MoodHappy,
+
+	"sad": MoodSad, "sorry": MoodSad, "miss": MoodSad, "cry": MoodSad,
+	"crying": MoodSad, "lost": MoodSad, "lonely": MoodSad, "hurt": MoodSad,
+	"died": MoodSad, "tired": MoodSad, "sick": MoodSad, "failed": MoodSad,
+	"unfortunately": MoodSad, "bad": MoodSad, "rip": MoodSad,
+
+	"angry": MoodAngry, "mad": MoodAngry, "hate": MoodAngry, "annoying": MoodAngry,
+	"annoyed": MoodAngry, "stupid": MoodAngry, "ugh": MoodAngry, "furious": MoodAngry,
+	"worst": MoodAngry, "broken": MoodAngry, "rude": MoodAngry, "stop": MoodAngry,
+
+	"shy": MoodShy, "blush": MoodShy, "blushing": MoodShy, "embarrassed": MoodShy,
+	"nervous": MoodShy, "crush": MoodShy, "cute": MoodShy, "awkward": MoodShy,
+	"um": MoodShy, "uh": MoodShy, "maybe": MoodShy,
+
+	"wow": MoodExcited, "omg": MoodExcited, "amazing": MoodExcited, "awesome": MoodExcited,
+	"excited": MoodExcited, "finally": MoodExcited, "incredible": MoodExcited,
+	"hype": MoodExcited, "woo": MoodExcited, "job": MoodExcited, "party": MoodExcited,
+}
+
+// DefaultLexicon returns a copy of the built-in sentiment lexicon
+func DefaultLexicon() Lexicon { return maps.Clone(builtinLexicon) }
+
+// validate checks that m is one of the known moods
+func (m Mood) validate() error {
+	if !slices.Contains(moodOrder[:], m) {
+		return fmt.Errorf("unknown mood %q, want happy, sad, angry, shy or excited", string(m))
+	}
+	return nil
+}
+
+// WithMoods tags faces and actions with moods, see SetMoods
+func WithMoods(moods Moods) Option {
+	return func(u *Uwuifier) {
+		u.SetMoods(moods)
+	}
+}
+
+// WithSentiment detects the mood of sentences with lexicon, see SetSentiment
+func WithSentiment(lexicon Lexicon) Option {
+	return func(u *Uwuifier) {
+		u.SetSentiment(lexicon)
+	}
+}
+
+// Moods returns a copy of the mood tags set with SetMoods
+func (u *Uwuifier) Moods() Moods {
+	moods := make(Moods, len(u.moods))
+	for expression, tags := range u.moods {
+		moods[expression] = slices.Clone(tags)
+	}
+	if len(moods) == 0 {
+		return nil
+	}
+	return moods
+}
+
+// Sentiment returns a copy of the lexicon moods are detected with, or nil
+// if sentiment detection is off
+func (u *Uwuifier) Sentiment() Lexicon { return maps.Clone(u.lexicon) }
+
+// MoodsOf returns the moods a face or action is tagged with
+func (u *Uwuifier) MoodsOf(expression string) []Mood {
+	return slices.Clone(u.filter().moodsOf(expression))
+}
+
+// SetMoods tags faces and actions with moods, overriding the tags of the
+// built-in ones. An empty list leaves an expression untagged.
+func (u *Uwuifier) SetMoods(moods Moods) error {
+	tags := make(Moods, len(moods))
+	for expression, list := range moods {
+		for _, mood := range list {
+			if err := mood.validate(); err != nil {
+				return fmt.Errorf("%q: %w", expression, err)
+			}
+		}
+		tags[expression] = slices.Clone(list)
+	}
+	if len(tags) == 0 {
+		tags = nil
+	}
+
+	u.moods = tags
+	u.changed()
+	return nil
+}
+
+// SetSentiment detects the mood of every sentence from the words in lexicon,
+// like DefaultLexicon, so the spaces stage prefers faces and actions tagged
+// with that mood. Without a matching expression untagged ones are picked,
+// and without those any. Sentences without lexicon words pick from every
+// expression as usual. A nil lexicon turns detection off, the default.
+func (u *Uwuifier) SetSentiment(lexicon Lexicon) error {
+	folded := make(Lexicon, len(lexicon))
+	for word, mood := range lexicon {
+		if err := mood.validate(); err != nil {
+			return fmt.Errorf("%q: %w", word, err)
+		}
+		folded[string(appendFold(nil, []byte(word)))] = mood
+	}
+	if lexicon == nil {
+		folded = nil
+	}
+
+	u.lexicon = folded
+	u.changed()
+	return nil
+}
+
+// DetectMood returns the mood the lexicon finds most often in sentence, or
+// "" if sentiment detection is off or no word is in the lexicon
+func (u *Uwuifier) DetectMood(sentence string) Mood {
+	if len(u.lexicon) == 0 {
+		return ""
+	}
+
+	sc := scratchPool.Get().(*scratch)
+	defer scratchPool.Put(sc)
+	return detectMood(u.lexicon, sc, sentence)
+}
+
+// detectMood counts the moods of the words of sentence found in lexicon,
+// folding them in sc.fold
+func detectMood[T text](lexicon Lexicon, sc *scratch, sentence T) Mood {
+	var counts [len(moodOrder)]int
+	for i := 0; i < len(sentence); {
+		start := i
+		for i < len(sentence) {
+			r, size := decodeRune(sentence, i)
+			if !unicode.IsLetter(r) && r != '\'' {
+				break
+			}
+			i += size
+		}
+		if start == i {
+			_, size := decodeRune(sentence, i)
+			i += size
+			continue
+		}
+
+		sc.fold = sc.fold[:0]
+		for j := start; j < i; {
+			r, size := decodeRune(sentence, j)
+			j += size
+			sc.fold = utf8.AppendRune(sc.fold, unicode.ToLower(r))
+		}
+		if mood, ok := lexicon[string(sc.fold)]; ok {
+			counts[slices.Index(moodOrder[:], mood)]++
+		}
+	}
+
+	best := -1
+	for i, count := range counts {
+		if count > 0 && (best < 0 || count > counts[best]) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return ""
+	}
+	return moodOrder[best]
+}
+
+// moodsOf returns the moods expression is tagged with
+func (f filter) moodsOf(expression string) []Mood {
+	if moods, ok := f.moods[expression]; ok {
+		return moods
+	}
+	return builtinMoods[expression]
+}
+
+// preferring returns f narrowed to the expressions of list tagged with
+// mood, or to the untagged ones if none can be picked, or f itself if
+// neither can
+func (f filter) preferring(mood Mood, list []string, w Weights) filter {
+	if mood == "" {
+		return f
+	}
+
+	for _, tier := range []moodTier{tierMatching, tierUntagged} {
+		narrowed := f
+		narrowed.mood, narrowed.tier = mood, tier
+		if w.available(list, narrowed) {
+			return narrowed
+		}
+	}
+	return f
+}
+
+// moodTier is which expressions a mood preference lets through
+type moodTier uint8
+
+const (
+	tierAny moodTier = iota
+	tierMatching
+	tierUntagged
+)
+
+// suits reports whether expression passes the mood preference of f
+func (f filter) suits(expression string) bool {
+	switch f.tier {
+	case tierMatching:
+		return slices.Contains(f.moodsOf(expression), f.mood)
+	case tierUntagged:
+		return len(f.moodsOf(expression)) == 0
+	}
+	return true
+}
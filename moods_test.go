@@ -0,0 +1,120 @@
+package gouwu
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDetectMood(t *testing.T) {
+	uwuifier := New(WithSentiment(DefaultLexicon()))
+
+	testCases := []struct {
+		input    string
+		expected Mood
+	}{
+		{"I got the job!", MoodExcited},
+		{"I'm so happy, thanks for the hug", MoodHappy},
+		{"Sorry, I miss you and I'm sad", MoodSad},
+		{"UGH this is so ANNOYING", MoodAngry},
+		{"um... you're cute", MoodShy},
+		{"The meeting is at noon", ""},
+		{"", ""},
+		{"glad-sad-sad", MoodSad},
+	}
+
+	for _, tc := range testCases {
+		if mood := uwuifier.DetectMood(tc.input); mood != tc.expected {
+			t.Errorf("DetectMood(%q) = %q, want %q", tc.input, mood, tc.expected)
+		}
+	}
+
+	if mood := New().DetectMood("I'm so happy"); mood != "" {
+		t.Errorf("DetectMood() without a lexicon = %q", mood)
+	}
+}
+
+func TestSentimentPrefersMatchingMood(t *testing.T) {
+	uwuifier := New(
+		WithSpaces(SpacesModifier{Faces: 0.5, Actions: 0.5}),
+		WithSentiment(DefaultLexicon()),
+	)
+
+	for i := 0; i < 200; i++ {
+		sentence := fmt.Sprintf("I am so happy today number%d yay", i)
+		result := uwuifier.UwuifySpaces(sentence)
+		for _, sad := range []string{";;w;;", "ÚwÚ", "*cries*", "*walks away*"} {
+			if strings.Contains(result, sad) {
+				t.Fatalf("UwuifySpaces(%q) = %q picked sad %q", sentence, result, sad)
+			}
+		}
+	}
+}
+
+func TestSentimentFallsBackToUntagged(t *testing.T) {
+	uwuifier := New(
+		WithSpaces(SpacesModifier{Faces: 1}),
+		WithFaces(";;w;;", "-_-", "UwU"),
+		WithMoods(Moods{"UwU": {MoodExcited}}),
+		WithSentiment(Lexicon{"happy": MoodHappy, "bored": MoodShy}),
+	)
+
+	// No happy face, so the untagged "-_-" is picked
+	counts := map[string]int{}
+	for i := 0; i < 100; i++ {
+		words := strings.Split(uwuifier.UwuifySpaces(fmt.Sprintf("happy%d happy", i)), " ")
+		counts[words[1]]++
+	}
+	if len(counts) != 1 || counts["-_-"] != 100 {
+		t.Errorf("faces picked = %v, want only the untagged one", counts)
+	}
+
+	// Without untagged faces anything goes
+	uwuifier.Faces = []string{";;w;;", "UwU"}
+	counts = map[string]int{}
+	for i := 0; i < 100; i++ {
+		words := strings.Split(uwuifier.UwuifySpaces(fmt.Sprintf("happy%d happy", i)), " ")
+		counts[words[1]]++
+	}
+	if counts[";;w;;"] == 0 || counts["UwU"] == 0 {
+		t.Errorf("faces picked = %v, want every face", counts)
+	}
+}
+
+func TestSentimentNeutralUnchanged(t *testing.T) {
+	input := "Hello world! This is a test sentence about the weather."
+	expected := New().UwuifySentence(input)
+	if result := New(WithSentiment(DefaultLexicon())).UwuifySentence(input); result != expected {
+		t.Errorf("UwuifySentence(%q) = %q, want %q", input, result, expected)
+	}
+}
+
+func TestSentimentSkipsCache(t *testing.T) {
+	uwuifier := New(WithCache(64), WithSpaces(SpacesModifier{Faces: 1}), WithSentiment(DefaultLexicon()))
+	plain := New(WithSpaces(SpacesModifier{Faces: 1}), WithSentiment(DefaultLexicon()))
+
+	for _, input := range []string{"word one two", "so sad word one two", "so happy word one two"} {
+		if result, expected := uwuifier.UwuifySentence(input), plain.UwuifySentence(input); result != expected {
+			t.Errorf("UwuifySentence(%q) = %q, want %q", input, result, expected)
+		}
+	}
+}
+
+func TestMoodValidation(t *testing.T) {
+	uwuifier := New()
+	if err := uwuifier.SetMoods(Moods{"UwU": {"smug"}}); err == nil {
+		t.Error("SetMoods() should reject unknown moods")
+	}
+	if err := uwuifier.SetSentiment(Lexicon{"meh": "bored"}); err == nil {
+		t.Error("SetSentiment() should reject unknown moods")
+	}
+
+	uwuifier.SetMoods(Moods{"UwU": {}})
+	if moods := uwuifier.MoodsOf("UwU"); len(moods) != 0 {
+		t.Errorf("MoodsOf(UwU) = %v, want untagged", moods)
+	}
+	if moods := uwuifier.MoodsOf(";;w;;"); !slices.Equal(moods, []Mood{MoodSad}) {
+		t.Errorf("MoodsOf(;;w;;) = %v, want built-in tags", moods)
+	}
+}
@@ -0,0 +1,161 @@
+package gouwu
+
+import "errors"
+
+// NyaModifier defines probabilities for the catgirl stage
+type NyaModifier struct {
+	// Endings is the probability that a sentence ends in a cat sound, like
+	// "hungry, nya!"
+	Endings float64 `json:"endings"`
+
+	// Emphasis is the probability that an exclamation gets a ":3"
+	Emphasis float64 `json:"emphasis"`
+
+	// Purrs is the probability that a word starting with "per", "pur" or
+	// "pr" is purred, like "purrfect"
+	Purrs float64 `json:"purrs"`
+
+	// Mews is the probability that a word starting with "new" mews, like
+	// "mews"
+	Mews float64 `json:"mews"`
+}
+
+// nyaEndings are the cat sounds sentences may end in
+var nyaEndings = []string{"nya", "nya~", "nyaa~", "meow", "mew"}
+
+// WithNya sets the catgirl stage probabilities
+func WithNya(nya NyaModifier) Option {
+	return func(u *Uwuifier) {
+		u.SetNyaModifier(nya)
+	}
+}
+
+// NyaModifier returns the catgirl stage probabilities
+func (u *Uwuifier) NyaModifier() NyaModifier { return u.nyaModifier }
+
+// SetNyaModifier sets the catgirl stage probabilities. All are 0 by
+// default, which turns the stage off.
+func (u *Uwuifier) SetNyaModifier(value NyaModifier) error {
+	for _, probability := range []float64{value.Endings, value.Emphasis, value.Purrs, value.Mews} {
+		if probability < 0 || probability > 1 {
+			return errors.New("nyaModifier values must be between 0 and 1")
+		}
+	}
+	u.nyaModifier = value
+	u.changed()
+	return nil
+}
+
+// UwuifyNya applies the catgirl stage: purrs and mews in words, and cat
+// sounds and ":3" at the end of sentences
+func (u *Uwuifier) UwuifyNya(sentence string) string {
+	return u.uwuifyString(sentence, stageNya)
+}
+
+// nyaWord purrs or mews sc.word and reports whether it did, in which case
+// the word rules leave it alone
+func (u *Uwuifier) nyaWord(sc *scratch) bool {
+	if u.nyaModifier.Purrs == 0 && u.nyaModifier.Mews == 0 || isAt(sc.word) || isURI(sc.word) {
+		return false
+	}
+
+	start, end := wordCore(sc.word)
+	core := sc.word[start:end]
+
+	r := u.randFor(sc.word)
+	purr := r.float() <= u.nyaModifier.Purrs
+	mew := r.float() <= u.nyaModifier.Mews
+
+	switch {
+	case mew && hasPrefixFold(core, "new"):
+		// Only the n changes, to the m before it, so the case is kept
+		core[0]--
+		return true
+	case purr:
+		n := purrPrefix(core)
+		if n == 0 {
+			return false
+		}
+		sc.spare = append(sc.spare[:0], sc.word[:start+1]...)
+		if isUpper(core[1]) {
+			sc.spare = append(sc.spare, "URR"...)
+		} else {
+			sc.spare = append(sc.spare, "urr"...)
+		}
+		sc.spare = append(sc.spare, sc.word[start+n:]...)
+		sc.word, sc.spare = sc.spare, sc.word
+		return true
+	}
+	return false
+}
+
+// nyaEnd adds a cat sound after sc.word if it ends a sentence and ":3" if
+// it ends an exclamation. Both are words of their own, so they go to
+// sc.tail, and the punctuation ending sc.word moves to the cat sound. last
+// is whether the word ends the text.
+func (u *Uwuifier) nyaEnd(sc *scratch, last bool) {
+	if u.nyaModifier.Endings == 0 && u.nyaModifier.Emphasis == 0 || isAt(sc.word) || isURI(sc.word) {
+		return
+	}
+
+	start, end := wordCore(sc.word)
+	if start == end {
+		return
+	}
+	rest := sc.word[end:]
+	if !last && !endsSentence(rest) {
+		return
+	}
+
+	r := u.randFor(sc.word)
+	ending := r.float() <= u.nyaModifier.Endings
+	emphasis := r.float() <= u.nyaModifier.Emphasis && trailingExclamation(sc.word) > 0 &&
+		u.accessibility != AccessibilityOmit
+	if !ending && !emphasis {
+		return
+	}
+
+	if ending {
+		sc.tail = append(sc.tail, ' ')
+		sc.tail = append(sc.tail, nyaEndings[r.intn(len(nyaEndings))]...)
+		sc.tail = append(sc.tail, rest...)
+		sc.word = append(sc.word[:end], ',')
+	}
+	if emphasis {
+		sc.tail = append(sc.tail, ' ')
+		sc.tail = u.appendFace(sc.tail, ":3")
+	}
+}
+
+// purrPrefix returns the length of the "per", "pur" or "pr" that core
+// starts with, or 0
+func purrPrefix(core []byte) int {
+	switch {
+	case hasPrefixFold(core, "purr"):
+		return 0
+	case hasPrefixFold(core, "per"), hasPrefixFold(core, "pur"):
+		return 3
+	case hasPrefixFold(core, "pr"):
+		return 2
+	}
+	return 0
+}
+
+// hasPrefixFold reports whether word starts with the lowercase ASCII prefix,
+// ignoring case
+func hasPrefixFold(word []byte, prefix string) bool {
+	if len(word) < len(prefix) {
+		return false
+	}
+	for i := range len(prefix) {
+		if word[i]|0x20 != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// isUpper reports whether c is an ASCII capital
+func isUpper(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
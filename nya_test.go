@@ -0,0 +1,116 @@
+package gouwu
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestNyaWords(t *testing.T) {
+	uwuifier := New(WithNya(NyaModifier{Purrs: 1, Mews: 1}))
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"perfect", "purrfect"},
+		{"Pretty", "Purretty"},
+		{"PERSON", "PURRSON"},
+		{"purpose", "purrpose"},
+		{"purr", "purr"},
+		{"new", "mew"},
+		{"News!", "Mews!"},
+		{"(NEW)", "(MEW)"},
+		{"apple", "apple"},
+		{"@pretty", "@pretty"},
+	}
+
+	for _, tc := range testCases {
+		if result := uwuifier.UwuifyNya(tc.input); result != tc.expected {
+			t.Errorf("UwuifyNya(%q) = %q, want %q", tc.input, result, tc.expected)
+		}
+	}
+}
+
+func TestNyaSkipsWordRules(t *testing.T) {
+	uwuifier := New(WithWords(1), WithNya(NyaModifier{Purrs: 1, Mews: 1}))
+
+	if result := uwuifier.UwuifyWords("perfect news"); result != "pewfect nyews" {
+		t.Errorf("UwuifyWords() = %q, want the word rules alone", result)
+	}
+	if result := uwuifier.UwuifySentence("perfect news"); result != "purrfect mews" {
+		t.Errorf("UwuifySentence() = %q, want purrs and mews left alone", result)
+	}
+}
+
+func TestNyaEndings(t *testing.T) {
+	endings := `(nya|nya~|nyaa~|meow|mew)`
+
+	testCases := []struct {
+		name     string
+		opts     []Option
+		input    string
+		expected string
+	}{
+		{"ending", []Option{WithNya(NyaModifier{Endings: 1})}, "i am hungry. feed me", `^i am hungry, ` + endings + `\. feed me, ` + endings + `$`},
+		{"ending before exclamation", []Option{WithNya(NyaModifier{Endings: 1})}, "hi!", `^hi, ` + endings + `!$`},
+		{"emphasis", []Option{WithNya(NyaModifier{Emphasis: 1})}, "wow! ok.", `^wow! :3 ok\.$`},
+		{"described emphasis", []Option{WithNya(NyaModifier{Emphasis: 1}), WithAccessibility(AccessibilityDescribe)}, "wow!", `^wow! \(cat face\)$`},
+		{"omitted emphasis", []Option{WithNya(NyaModifier{Emphasis: 1}), WithAccessibility(AccessibilityOmit)}, "wow!", `^wow!$`},
+		{"protected", []Option{WithNya(NyaModifier{Endings: 1, Emphasis: 1}), WithProtectedWords("Go")}, "Go!", `^Go!$`},
+		{"off", nil, "hi!", `^hi!$`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := New(tc.opts...).UwuifyNya(tc.input); !regexp.MustCompile(tc.expected).MatchString(result) {
+				t.Errorf("UwuifyNya(%q) = %q, want %s", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestNyaEndingWords(t *testing.T) {
+	uwuifier := New(
+		WithWords(0),
+		WithExclamations(0),
+		WithSpaces(SpacesModifier{Faces: 0.3, Actions: 0.3, Stutters: 0.4}),
+		WithNya(NyaModifier{Endings: 1, Emphasis: 1}),
+	)
+
+	// Cat sounds and ":3" are spaced as words of their own, as if the
+	// stages ran one after another
+	for _, input := range []string{"i am hungry! feed me", "wow! so pretty. ok?", "hello"} {
+		expected := uwuifier.UwuifySpaces(uwuifier.UwuifyNya(input))
+		if result := uwuifier.UwuifySentence(input); result != expected {
+			t.Errorf("UwuifySentence(%q) = %q, want %q", input, result, expected)
+		}
+	}
+
+	// The word after a cat sound still starts a sentence
+	uwuifier = New(WithWords(0), WithExclamations(0), WithSpaces(SpacesModifier{}), WithNya(NyaModifier{Endings: 1}),
+		WithCapitalization(CapitalizationSentence))
+	expected := `^I am hungry, (nya|nya~|nyaa~|meow|mew)! Feed me, (nya|nya~|nyaa~|meow|mew)$`
+	if result := uwuifier.UwuifySentence("i am hungry! feed me"); !regexp.MustCompile(expected).MatchString(result) {
+		t.Errorf("UwuifySentence() = %q, want %s", result, expected)
+	}
+}
+
+func TestNyaWithCache(t *testing.T) {
+	nya := WithNya(NyaModifier{Endings: 0.5, Emphasis: 0.5, Purrs: 0.5, Mews: 0.5})
+	cached := New(nya, WithCache(64))
+	plain := New(nya)
+
+	for _, input := range []string{"pretty news!", "so pretty", "pretty news! so pretty", "new"} {
+		if result, expected := cached.UwuifySentence(input), plain.UwuifySentence(input); result != expected {
+			t.Errorf("UwuifySentence(%q) = %q, want %q", input, result, expected)
+		}
+	}
+}
+
+func TestSetNyaModifier(t *testing.T) {
+	for _, value := range []NyaModifier{{Endings: -1}, {Emphasis: 2}, {Purrs: 1.1}, {Mews: -0.5}} {
+		if err := New().SetNyaModifier(value); err == nil {
+			t.Errorf("SetNyaModifier(%+v) should have errored", value)
+		}
+	}
+}
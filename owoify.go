@@ -0,0 +1,254 @@
+package gouwu
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// OwoifyLevel is a level of the owoify JavaScript library, each one
+// escalating the one before
+type OwoifyLevel int
+
+const (
+	// OwoifyOwo applies the owo mappings, the mildest level
+	OwoifyOwo OwoifyLevel = iota + 1
+
+	// OwoifyUwu adds the uwu mappings, like "th" to "f" and kaomoji for
+	// punctuation
+	OwoifyUwu
+
+	// OwoifyUvu adds the uvu mappings, like "o" to "owo"
+	OwoifyUvu
+)
+
+// owoKaomoji replace full stops and commas at the uwu level
+var owoKaomoji = []string{
+	"(ᵘʷᵘ)", "(ᵘﻌᵘ)", "(◡ ω ◡)", "(◡ ꒳ ◡)", "(◡ w ◡)", "(◡ ሠ ◡)", "(˘ω˘)", "(⑅˘꒳˘)", "(˘ᵕ˘)",
+	"(˘ሠ˘)", "(˘³˘)", "(˘ε˘)", "(„ᵕᴗᵕ„)", "(ㅅꈍ ˘ ꈍ)", "( ｡ᵘ ᵕ ᵘ ｡)", "( ᵘ ꒳ ᵘ ✼)",
+	"( ˘ᴗ˘ )", "(ᵕᴗ ᵕ⁎)", "*:･ﾟ✧(ꈍᴗꈍ)✧･ﾟ:*", "(。U ω U。)", "(U ᵕ U❁)", "(U ﹏ U)",
+	"(◦ᵕ ˘ ᵕ◦)", "ღ(U꒳Uღ)", "♥(。U ω U。)", "(ᴜ‿ᴜ✿)", "~(˘▾˘~)", "(｡ᴜ‿‿ᴜ｡)",
+}
+
+// owoExclamations replace runs of exclamation marks and semicolons at the
+// uwu level
+var owoExclamations = []string{
+	"(・`ω´・)", ";;w;;", "OwO", "UwU", ">w<", "^w^", "ÚwÚ", "^-^", ":3", "x3",
+}
+
+// owoRule is a mapping of the owoify library. A rule with choices replaces
+// each of its matches with one of them, picked at random, and a rule with a
+// locate function finds its matches with it rather than the pattern.
+type owoRule struct {
+	pattern     string
+	replacement string
+	choices     []string
+	locate      locateFunc
+}
+
+// owoSpecific are the mappings of every level, applied first
+var owoSpecific = []owoRule{
+	{pattern: `([Ff])uc`, replacement: "${1}wuc"},
+	{pattern: `([Mm])om`, replacement: "${1}wom"},
+	{pattern: `\b([Tt])ime\b`, replacement: "${1}im"},
+	{pattern: `^([Mm])e$`, replacement: "${1}we"},
+	{pattern: `n([aeiou])`, replacement: "ny${1}"},
+	{pattern: `N([aeiou])`, replacement: "Ny${1}"},
+	{pattern: `N([AEIOU])`, replacement: "NY${1}"},
+	{pattern: `([Oo])ver`, replacement: "${1}wor"},
+	{pattern: `ove`, replacement: "uv"},
+	{pattern: `OVE`, replacement: "UV"},
+	{pattern: `\b(ha|hah|heh|hehe)+\b`, replacement: "hehe xD"},
+	{pattern: `\b([Tt])he\b`, replacement: "${1}eh"},
+	{pattern: `\byou\b`, replacement: "u"},
+	{pattern: `\b(You|YOU)\b`, replacement: "U"},
+	{pattern: `\bread`, replacement: "wead"},
+	{pattern: `\bRead`, replacement: "Wead"},
+	{pattern: `\b([Ww])orse`, replacement: "${1}ose"},
+}
+
+// owoUvu are the mappings of the uvu level
+var owoUvu = []owoRule{
+	{pattern: `o`, choices: []string{"owo", "0w0"}},
+	{pattern: `O`, choices: []string{"OwO", "0W0"}},
+	{pattern: `ew`, replacement: "uwu"},
+	{pattern: `EW`, replacement: "UWU"},
+	{pattern: `([Hh])ey`, replacement: "${1}ay"},
+	{pattern: `([Dd])ead`, replacement: "${1}ed"},
+	{pattern: `n[aeiou]*t`, replacement: "nd"},
+	{pattern: `N[AEIOU]*T`, replacement: "ND"},
+}
+
+// owoUwu are the mappings of the uwu level and above
+var owoUwu = []owoRule{
+	{pattern: `[({<]`, replacement: "｡･:*:･ﾟ★,｡･:*:･ﾟ☆"},
+	{pattern: `[)}>]`, replacement: "☆ﾟ･:*:･｡,★ﾟ･:*:･｡"},
+	{pattern: `[.,]`, choices: owoKaomoji, locate: locatePeriodsAndCommas},
+	{pattern: `[!;]+`, choices: owoExclamations},
+	{pattern: `\bthat\b`, replacement: "dat"},
+	{pattern: `\bThat\b`, replacement: "Dat"},
+	{pattern: `\bTHAT\b`, replacement: "DAT"},
+	{pattern: `th`, replacement: "f"},
+	{pattern: `T[Hh]`, replacement: "F"},
+	{pattern: `le$`, replacement: "wal"},
+	{pattern: `ve`, replacement: "we"},
+	{pattern: `VE`, replacement: "WE"},
+	{pattern: `ry`, replacement: "wwy"},
+	{pattern: `RY`, replacement: "WWY"},
+	{pattern: `[rl]`, replacement: "w"},
+	{pattern: `[RL]`, replacement: "W"},
+}
+
+// owoOwo are the mappings of every level, applied last
+var owoOwo = []owoRule{
+	{pattern: `n([aeiou])`, replacement: "ny${1}"},
+	{pattern: `N([aeiou])`, replacement: "Ny${1}"},
+	{pattern: `N([AEIOU])`, replacement: "NY${1}"},
+	{pattern: `ll`, replacement: "ww"},
+	{pattern: `LL`, replacement: "WW"},
+	{pattern: `[aeiur]l$`, replacement: "wl"},
+	{pattern: `([Oo])ld`, replacement: "${1}wld"},
+	{pattern: `([Oo])l`, replacement: "${1}wl"},
+	{pattern: `[lr]o`, replacement: "wo"},
+	{pattern: `[LR]O`, replacement: "WO"},
+	{pattern: `([bcdfghjkmnpqstxyz])o`, replacement: "${1}wo"},
+	{pattern: `([BCDFGHJKMNPQSTXYZ])O`, replacement: "${1}WO"},
+	{pattern: `[vw]le`, replacement: "wal"},
+	{pattern: `([Ff])i`, replacement: "${1}wi"},
+	{pattern: `[Vv]er`, replacement: "wer"},
+	{pattern: `([Pp])oi`, replacement: "${1}woi"},
+	{pattern: `([dfghjpqrstxyz])le$`, replacement: "${1}wal"},
+	{pattern: `([bcdfghjkmnpqstxyz])r`, replacement: "${1}w"},
+	{pattern: `ly`, replacement: "wy"},
+	{pattern: `([Pp])le`, replacement: "${1}we"},
+	{pattern: `nr`, replacement: "nw"},
+	{pattern: `([Mm])em`, replacement: "${1}wem"},
+	{pattern: `nywo`, replacement: "nyo"},
+	{pattern: `NYWO`, replacement: "NYO"},
+}
+
+// owoifyRules are the compiled mappings of each level, in the order the
+// owoify library applies them
+var owoifyRules = [...][]UwuReplacement{
+	OwoifyOwo: compileOwo(owoSpecific, owoOwo),
+	OwoifyUwu: compileOwo(owoSpecific, owoUwu, owoOwo),
+	OwoifyUvu: compileOwo(owoSpecific, owoUvu, owoUwu, owoOwo),
+}
+
+// compileOwo compiles lists of mappings into replacements
+func compileOwo(lists ...[]owoRule) []UwuReplacement {
+	var compiled []UwuReplacement
+	for _, list := range lists {
+		for _, rule := range list {
+			compiled = append(compiled, UwuReplacement{
+				Pattern:     regexp.MustCompile(rule.pattern),
+				Replacement: rule.replacement,
+				choices:     rule.choices,
+				locate:      rule.locate,
+			})
+		}
+	}
+	return compiled
+}
+
+// halfwidthStop is the character around the commas of the startrails the
+// brackets turn into
+const halfwidthStop = "｡"
+
+// locatePeriodsAndCommas returns every '.' and ',' that is neither a decimal
+// point nor a comma of a startrail. Unlike a regular expression it looks at
+// the characters around a mark without consuming them, so every mark of a
+// run like "..." is found.
+func locatePeriodsAndCommas(src []byte) [][]int {
+	var matches [][]int
+	for i, c := range src {
+		if c != '.' && c != ',' {
+			continue
+		}
+		next := src[i+1:]
+		if bytes.HasSuffix(src[:i], []byte(halfwidthStop)) || bytes.HasPrefix(next, []byte(halfwidthStop)) ||
+			len(next) > 0 && next[0] >= '0' && next[0] <= '9' {
+			continue
+		}
+		matches = append(matches, []int{i, i + 1})
+	}
+	return matches
+}
+
+// String returns the name of the level, or "" for none
+func (l OwoifyLevel) String() string {
+	switch l {
+	case 0:
+		return ""
+	case OwoifyOwo:
+		return "owo"
+	case OwoifyUwu:
+		return "uwu"
+	case OwoifyUvu:
+		return "uvu"
+	}
+	return fmt.Sprintf("OwoifyLevel(%d)", int(l))
+}
+
+// MarshalText encodes the level as its name
+func (l OwoifyLevel) MarshalText() ([]byte, error) {
+	if err := l.validate(); err != nil {
+		return nil, err
+	}
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText decodes a level from its name
+func (l *OwoifyLevel) UnmarshalText(text []byte) error {
+	for _, level := range []OwoifyLevel{0, OwoifyOwo, OwoifyUwu, OwoifyUvu} {
+		if level.String() == string(text) {
+			*l = level
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown owoify level %q, want owo, uwu or uvu", text)
+}
+
+// validate checks that l is a known level or none
+func (l OwoifyLevel) validate() error {
+	if l < 0 || l > OwoifyUvu {
+		return errors.New("unknown owoify level")
+	}
+	return nil
+}
+
+// WithOwoifyLevel replaces the built-in word rules with the mappings of an
+// owoify level, see SetOwoifyLevel
+func WithOwoifyLevel(level OwoifyLevel) Option {
+	return func(u *Uwuifier) {
+		u.SetOwoifyLevel(level)
+	}
+}
+
+// OwoifyLevel returns the owoify level whose mappings replace the built-in
+// word rules, or 0 if they are used as they are
+func (u *Uwuifier) OwoifyLevel() OwoifyLevel { return u.owoify }
+
+// SetOwoifyLevel replaces the built-in word rules with the mappings of the
+// owoify library up to a level. Like the built-in rules, each mapping is
+// applied with the words modifier as probability, so a modifier of 1
+// owoifies words like the library does. A level of 0 keeps the built-in
+// rules, the default.
+func (u *Uwuifier) SetOwoifyLevel(level OwoifyLevel) error {
+	if err := level.validate(); err != nil {
+		return err
+	}
+	u.owoify = level
+	u.changed()
+	return nil
+}
+
+// wordRules returns the built-in word rules, or the owoify mappings
+// replacing them
+func (u *Uwuifier) wordRules() []UwuReplacement {
+	if u.owoify != 0 {
+		return owoifyRules[u.owoify]
+	}
+	return u.uwuMap
+}
@@ -0,0 +1,157 @@
+package gouwu
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestOwoifyWords(t *testing.T) {
+	testCases := []struct {
+		level    OwoifyLevel
+		input    string
+		expected string
+	}{
+		{OwoifyOwo, "hello", "hewwo"},
+		{OwoifyOwo, "the", "teh"},
+		{OwoifyOwo, "you", "u"},
+		{OwoifyOwo, "You", "U"},
+		{OwoifyOwo, "me", "mwe"},
+		{OwoifyOwo, "love", "luv"},
+		{OwoifyOwo, "over", "owor"},
+		{OwoifyOwo, "haha", "hehe xD"},
+		{OwoifyOwo, "old", "owld"},
+		{OwoifyOwo, "nice", "nyice"},
+		{OwoifyOwo, "NOT", "NYOT"},
+		{OwoifyOwo, "really", "reawwy"},
+		{OwoifyOwo, "that", "that"},
+		{OwoifyUwu, "that", "dat"},
+		{OwoifyUwu, "That", "Dat"},
+		{OwoifyUwu, "thing", "fwing"},
+		{OwoifyUwu, "really", "weawwy"},
+		{OwoifyUwu, "very", "wewwy"},
+		{OwoifyUwu, "3.14", "3.14"},
+		{OwoifyUvu, "hey", "hay"},
+		{OwoifyUvu, "dead", "ded"},
+		{OwoifyUvu, "new", "nyuwu"},
+	}
+
+	for _, tc := range testCases {
+		uwuifier := New(WithWords(1), WithOwoifyLevel(tc.level))
+		if result := uwuifier.UwuifyWords(tc.input); result != tc.expected {
+			t.Errorf("UwuifyWords(%q) at %s = %q, want %q", tc.input, tc.level, result, tc.expected)
+		}
+	}
+}
+
+func TestOwoifyChoices(t *testing.T) {
+	testCases := []struct {
+		level    OwoifyLevel
+		input    string
+		expected string
+	}{
+		{OwoifyOwo, "hi. bye!", `^hi\. bye!$`},
+		{OwoifyUwu, "hi. bye!", `^hi\S*\(.+\)\S* bye\S+$`},
+		{OwoifyUwu, "wait...", `^wait[^.]+$`},
+		{OwoifyUwu, "so.,", `^swo[^.,]+$`},
+		{OwoifyUwu, "(hi)", `^｡･:\*:･ﾟ★,｡･:\*:･ﾟ☆hi☆ﾟ･:\*:･｡,★ﾟ･:\*:･｡$`},
+		{OwoifyUvu, "so", `^s(wowo|0w0)$`},
+		{OwoifyUvu, "SO", `^S(WOwO|0W0)$`},
+	}
+
+	for _, tc := range testCases {
+		uwuifier := New(WithWords(1), WithOwoifyLevel(tc.level))
+		if result := uwuifier.UwuifyWords(tc.input); !regexp.MustCompile(tc.expected).MatchString(result) {
+			t.Errorf("UwuifyWords(%q) at %s = %q, want a match for %s", tc.input, tc.level, result, tc.expected)
+		}
+	}
+}
+
+func TestOwoifyChoicePerMatch(t *testing.T) {
+	uwuifier := New(WithWords(1), WithOwoifyLevel(OwoifyUwu))
+	result := uwuifier.UwuifyWords("a,b,c,d,e,f,g,h")
+
+	picked := make(map[string]bool)
+	for _, kaomoji := range owoKaomoji {
+		if strings.Contains(result, kaomoji) {
+			picked[kaomoji] = true
+		}
+	}
+	if strings.Contains(result, ",") || len(picked) < 2 {
+		t.Errorf("UwuifyWords() = %q, want every comma replaced by its own pick", result)
+	}
+}
+
+func TestOwoifyASCIIOnly(t *testing.T) {
+	uwuifier := New(WithWords(1), WithOwoifyLevel(OwoifyUwu), WithASCIIOnly())
+	if result := uwuifier.UwuifyWords("(hi), that."); result != "(hi), dat." {
+		t.Errorf("UwuifyWords() = %q, want the kaomoji left out", result)
+	}
+}
+
+func TestOwoifyLevelKeepsCustomRules(t *testing.T) {
+	uwuifier := New(
+		WithWords(1), WithOwoifyLevel(OwoifyOwo),
+		WithRules(Rule{Pattern: "hewwo", Replacement: "hai"}),
+		WithDictionary(map[string]string{"bye": "bai"}),
+	)
+	if result := uwuifier.UwuifyWords("hello bye"); result != "hai bai" {
+		t.Errorf("UwuifyWords() = %q, want %q", result, "hai bai")
+	}
+}
+
+func TestOwoifyLevelText(t *testing.T) {
+	for _, level := range []OwoifyLevel{0, OwoifyOwo, OwoifyUwu, OwoifyUvu} {
+		data, err := json.Marshal(level)
+		if err != nil {
+			t.Fatalf("Marshal(%v) error = %v", level, err)
+		}
+		var decoded OwoifyLevel
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != level {
+			t.Errorf("Unmarshal(%s) = %v, %v, want %v", data, decoded, err, level)
+		}
+	}
+
+	var level OwoifyLevel
+	if err := level.UnmarshalText([]byte("owu")); err == nil || !strings.Contains(err.Error(), "owo, uwu or uvu") {
+		t.Errorf("UnmarshalText() error = %v, want the known levels", err)
+	}
+	if err := New().SetOwoifyLevel(4); err == nil {
+		t.Error("SetOwoifyLevel(4) succeeded")
+	}
+	if _, err := json.Marshal(OwoifyLevel(-1)); err == nil {
+		t.Error("Marshal(-1) succeeded")
+	}
+}
+
+func TestOwoifyPersonas(t *testing.T) {
+	uwuifier := New(WithSpaces(SpacesModifier{Faces: 1}), WithNya(NyaModifier{Endings: 1}))
+
+	testCases := []struct {
+		persona  string
+		expected string
+	}{
+		{"owo", "hewwo there, u are reawwy nyice"},
+		{"uwu", "hewwo fewe"},
+		{"uvu", "heww"},
+	}
+
+	for _, tc := range testCases {
+		result, err := uwuifier.UwuifyAs(tc.persona, "hello there, you are really nice")
+		if err != nil {
+			t.Fatalf("UwuifyAs(%q) error = %v", tc.persona, err)
+		}
+		if !strings.HasPrefix(result, tc.expected) || strings.Contains(result, "nya") {
+			t.Errorf("UwuifyAs(%q) = %q, want it to start with %q and nothing else", tc.persona, result, tc.expected)
+		}
+	}
+
+	config, err := ParseConfig([]byte(`{"owoify": "uvu"}`))
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	if config.Owoify != OwoifyUvu {
+		t.Errorf("ParseConfig() owoify = %v, want %v", config.Owoify, OwoifyUvu)
+	}
+}
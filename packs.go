@@ -0,0 +1,127 @@
+package gouwu
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Pack is a set of faces, actions and exclamations in one style
+type Pack struct {
+	Faces        []string `json:"faces"`
+	Actions      []string `json:"actions"`
+	Exclamations []string `json:"exclamations"`
+}
+
+// defaultActions are the actions of the kaomoji and ASCII packs
+var defaultActions = []string{
+	"*blushes*", "*whispers to self*", "*cries*", "*screams*",
+	"*sweats*", "*twerks*", "*runs away*", "*screeches*",
+	"*walks away*", "*sees bulge*", "*looks at you*",
+	"*notices buldge*", "*starts twerking*", "*huggles tightly*",
+	"*boops your nose*",
+}
+
+// packs are the built-in expression packs
+var packs = map[string]Pack{
+	"kaomoji": {
+		Faces: []string{
+			"(・`ω´・)", ";;w;;", "OwO", "UwU", ">w<",
+			"^w^", "ÚwÚ", "^-^", ":3", "x3",
+		},
+		Actions:      defaultActions,
+		Exclamations: []string{"!?", "?!!", "?!?1", "!!11", "?!?!"},
+	},
+	"emoji": {
+		Faces: []string{
+			"🥺", "👉👈", "😳", "🥰", "😊", "😭", "😤", "🙈", "✨", "💕",
+		},
+		Actions: []string{
+			"*blushes* 😳", "*cries* 😭", "*hides* 🙈", "*huggles tightly* 🤗",
+			"*boops your nose* 👉👃", "*sparkles* ✨", "*pouts* 😤",
+			"*runs away* 🏃", "*sends hearts* 💕",
+		},
+		// The emoji come first so the words still end in '!' or '?'
+		Exclamations: []string{"✨!", "😳?!", "💕!!", "😱?!?!", "🥺!!"},
+	},
+	"ascii": {
+		Faces: []string{
+			"OwO", "UwU", ">w<", "^w^", "^-^", ":3", "x3",
+			"owo", "uwu", ">_<", "T_T", ";_;", "^_^", "o.O",
+		},
+		Actions:      defaultActions,
+		Exclamations: []string{"!?", "?!!", "?!?1", "!!11", "?!?!"},
+	},
+}
+
+// WithPack replaces the faces, actions and exclamations with a built-in
+// pack, see LookupPack. Unknown packs are ignored.
+func WithPack(name string) Option {
+	return func(u *Uwuifier) {
+		u.SetPack(name)
+	}
+}
+
+// WithASCIIOnly guarantees that nothing inserted contains non-ASCII runes,
+// see SetASCIIOnly
+func WithASCIIOnly() Option {
+	return func(u *Uwuifier) {
+		u.SetASCIIOnly(true)
+	}
+}
+
+// Packs returns the names of the built-in expression packs
+func Packs() []string {
+	return slices.Sorted(maps.Keys(packs))
+}
+
+// LookupPack returns a copy of a built-in expression pack: "kaomoji" holds
+// the default expressions, "emoji" Unicode emoji and "ascii" only ASCII.
+func LookupPack(name string) (Pack, error) {
+	pack, ok := packs[name]
+	if !ok {
+		return Pack{}, fmt.Errorf("unknown pack %q, want one of %s", name, strings.Join(Packs(), ", "))
+	}
+	return Pack{
+		Faces:        slices.Clone(pack.Faces),
+		Actions:      slices.Clone(pack.Actions),
+		Exclamations: slices.Clone(pack.Exclamations),
+	}, nil
+}
+
+// ASCIIOnly reports whether the uwuifier only inserts ASCII
+func (u *Uwuifier) ASCIIOnly() bool { return u.asciiOnly }
+
+// SetPack replaces the faces, actions and exclamations with a built-in pack
+func (u *Uwuifier) SetPack(name string) error {
+	pack, err := LookupPack(name)
+	if err != nil {
+		return err
+	}
+	u.Faces, u.Actions, u.Exclamations = pack.Faces, pack.Actions, pack.Exclamations
+	u.changed()
+	return nil
+}
+
+// SetASCIIOnly switches the ASCII-only guarantee on or off. With it on,
+// faces, actions, exclamations and triggered actions containing non-ASCII
+// runes are never picked, as if they were not in the lists, dictionary
+// entries and custom rules with non-ASCII replacements are skipped, and
+// words starting with a non-ASCII rune are neither stuttered nor lowered.
+// Text from the input is left as it is.
+func (u *Uwuifier) SetASCIIOnly(enabled bool) {
+	u.asciiOnly = enabled
+	u.changed()
+}
+
+// isASCII reports whether s holds only ASCII
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
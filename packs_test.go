@@ -0,0 +1,115 @@
+package gouwu
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPacks(t *testing.T) {
+	if names := Packs(); !reflect.DeepEqual(names, []string{"ascii", "emoji", "kaomoji"}) {
+		t.Errorf("Packs() = %v", names)
+	}
+
+	for _, name := range Packs() {
+		pack, err := LookupPack(name)
+		if err != nil {
+			t.Errorf("LookupPack(%q) error = %v", name, err)
+			continue
+		}
+		if len(pack.Faces) == 0 || len(pack.Actions) == 0 || len(pack.Exclamations) == 0 {
+			t.Errorf("pack %q has an empty list: %+v", name, pack)
+		}
+		for _, exclamation := range pack.Exclamations {
+			// Exclamations become the end of a word, so they hold no spaces
+			// and end like the '!' or '?' they replace, or the 1 of "!!11"
+			if strings.Contains(exclamation, " ") || strings.IndexByte("!?1", exclamation[len(exclamation)-1]) < 0 {
+				t.Errorf("pack %q exclamation %q must end in '!', '?' or '1' without spaces", name, exclamation)
+			}
+		}
+
+		uwuifier := New(WithPack(name))
+		if !reflect.DeepEqual(uwuifier.Faces, pack.Faces) || !reflect.DeepEqual(uwuifier.Actions, pack.Actions) ||
+			!reflect.DeepEqual(uwuifier.Exclamations, pack.Exclamations) {
+			t.Errorf("WithPack(%q) did not set the pack expressions", name)
+		}
+	}
+
+	kaomoji, _ := LookupPack("kaomoji")
+	kaomoji.Faces[0] = "changed"
+	if uwuifier := New(); uwuifier.Faces[0] == "changed" {
+		t.Error("LookupPack() should return a copy")
+	}
+
+	ascii, _ := LookupPack("ascii")
+	for _, list := range [][]string{ascii.Faces, ascii.Actions, ascii.Exclamations} {
+		for _, expression := range list {
+			if !isASCII(expression) {
+				t.Errorf("ascii pack holds %q", expression)
+			}
+		}
+	}
+
+	if err := New().SetPack("nope"); err == nil {
+		t.Error("SetPack() should reject unknown packs")
+	}
+}
+
+func TestASCIIOnly(t *testing.T) {
+	input := "the quick brown fox jumps over the lazy dog and runs far away! really? yes"
+
+	testCases := []struct {
+		name string
+		opts []Option
+	}{
+		{"kaomoji faces", []Option{WithSpaces(SpacesModifier{Faces: 1})}},
+		{"emoji actions", []Option{WithPack("emoji"), WithSpaces(SpacesModifier{Actions: 1})}},
+		{"emoji exclamations", []Option{WithPack("emoji")}},
+		{"stutters", []Option{WithSpaces(SpacesModifier{Stutters: 1})}},
+		{"triggers", []Option{WithTriggers(Trigger{Keywords: []string{"fox"}, Action: "*🦊*", Probability: 1})}},
+		{"dictionary", []Option{WithDictionary(map[string]string{"dog": "🐶"})}},
+		{"rules", []Option{WithRules(Rule{Pattern: "a", Replacement: "ä"})}},
+		{"upstream parity", []Option{WithSpaces(SpacesModifier{Faces: 0.5, Stutters: 0.5}), WithUpstreamParity()}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uwuifier := New(append(tc.opts, WithASCIIOnly())...)
+			for _, sentence := range []string{input, strings.ToUpper(input), "ébène über" + input} {
+				result := uwuifier.UwuifySentence(sentence)
+				if inserted := strings.Map(func(r rune) rune {
+					if strings.ContainsRune(sentence, r) {
+						return -1
+					}
+					return r
+				}, result); !isASCII(inserted) {
+					t.Errorf("UwuifySentence(%q) = %q, inserted %q", sentence, result, inserted)
+				}
+			}
+		})
+	}
+}
+
+func TestASCIIOnlyDropsExpressions(t *testing.T) {
+	uwuifier := New(
+		WithFaces("🥺", "UwU"),
+		WithSpaces(SpacesModifier{Faces: 1}),
+		WithASCIIOnly(),
+	)
+
+	for _, word := range []string{"hello", "world", "gopher", "uwu"} {
+		if result := uwuifier.UwuifySpaces(word); result != word+" UwU" {
+			t.Errorf("UwuifySpaces(%q) = %q, want %q", word, result, word+" UwU")
+		}
+	}
+
+	uwuifier = New(WithPack("emoji"), WithSpaces(SpacesModifier{Faces: 1}), WithASCIIOnly())
+	if result := uwuifier.UwuifySpaces("hello world"); !isASCII(result) {
+		t.Errorf("UwuifySpaces() = %q, want no faces", result)
+	}
+
+	uwuifier = New(WithSpaces(SpacesModifier{Faces: 1}), WithASCIIOnly(), WithUpstreamParity())
+	if result := uwuifier.UwuifySpaces("hello world this is a test"); !isASCII(result) {
+		t.Errorf("UwuifySpaces() in parity mode = %q", result)
+	}
+}
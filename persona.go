@@ -0,0 +1,280 @@
+package gouwu
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Persona is a way of speaking, like a shy or a catgirl one. Its profile
+// bundles the stages, rules, expressions and modifiers it changes about the
+// uwuifier it is used with; stages are turned on and off by their
+// modifiers. Personas cannot extend other profiles.
+type Persona struct {
+	Description string  `json:"description,omitempty"`
+	Profile     Profile `json:"profile"`
+}
+
+// personas are the built-in personas
+var personas = map[string]Persona{
+	"default": {
+		Description: "the uwuifier as it is",
+	},
+	"catgirl": {
+		Description: "purrs, mews and ends sentences in nya",
+		Profile: Profile{
+			Modifiers: &ModifierOverrides{
+				Nya: &NyaOverrides{Endings: ptr(0.5), Emphasis: ptr(0.3), Purrs: ptr(1.0), Mews: ptr(1.0)},
+			},
+			Faces:   []string{":3", "x3", "=^w^=", "(=^･ω･^=)", "ฅ^•ﻌ•^ฅ", "(=ↀωↀ=)"},
+			Actions: []string{"*purrs*", "*nuzzles you*", "*paws at you*", "*licks paw*", "*knocks things off the table*"},
+			Moods: Moods{
+				"=^w^=":                         {MoodHappy},
+				"(=^･ω･^=)":                     {MoodHappy},
+				"(=ↀωↀ=)":                       {MoodExcited},
+				"*purrs*":                       {MoodHappy},
+				"*nuzzles you*":                 {MoodHappy, MoodShy},
+				"*paws at you*":                 {MoodExcited},
+				"*licks paw*":                   {MoodShy},
+				"*knocks things off the table*": {MoodAngry},
+			},
+		},
+	},
+	"baby": {
+		Description: "baby talk with widdle words and stretched vowels",
+		Profile: Profile{
+			Modifiers: &ModifierOverrides{
+				Words:      ptr(1.0),
+				Elongation: &ElongationOverrides{Vowels: ptr(0.3)},
+			},
+			Faces:        []string{"(｡◕‿◕｡)", "(◕ᴗ◕✿)", "(っ◔◡◔)っ", "(｡•́︿•̀｡)", "^w^"},
+			Actions:      []string{"*giggles*", "*claps hands*", "*waddles*", "*sucks thumb*", "*makes grabby hands*"},
+			Exclamations: []string{"!!", "?!", "!!!"},
+			Phonetics:    &PhoneticsOverrides{InitialTh: ptr(true), Diminutives: ptr(true)},
+			Rules: []Rule{
+				{Pattern: `([a-z])s$`, Replacement: "${1}sh"},
+			},
+			Dictionary: map[string]string{
+				"little": "widdle", "small": "smol", "yes": "yesh", "stomach": "tummy",
+				"dog": "doggy", "cat": "kitty", "thank": "fank", "thanks": "fanks",
+				"bottle": "baba", "blanket": "bankie", "sleep": "nappy", "hurt": "boo-boo",
+			},
+		},
+	},
+	"owo": owoifyPersona(OwoifyOwo, "the owo level of the owoify library"),
+	"uwu": owoifyPersona(OwoifyUwu, "the uwu level of the owoify library"),
+	"uvu": owoifyPersona(OwoifyUvu, "the uvu level of the owoify library"),
+	"shy": {
+		Description: "quiet and hesitant, with lots of ellipses",
+		Profile: Profile{
+			Modifiers: &ModifierOverrides{
+				Spaces: &SpacesOverrides{Faces: ptr(0.05), Actions: ptr(0.05), Stutters: ptr(0.3)},
+			},
+			Faces:        []string{"(⁄ ⁄•⁄ω⁄•⁄ ⁄)", "(//ω//)", "(〃▽〃)", "(/ω＼)", ">w<", "(,,>﹏<,,)"},
+			Actions:      []string{"*blushes*", "*whispers to self*", "*hides behind paws*", "*fidgets*", "*pokes fingers together*", "*looks away*"},
+			Exclamations: []string{"...", "...?", "..!"},
+			Rules: []Rule{
+				{Pattern: `[.,;]$`, Replacement: "..."},
+			},
+		},
+	},
+}
+
+// owoifyPersona returns a persona owoifying every word at level, and doing
+// nothing the owoify library does not
+func owoifyPersona(level OwoifyLevel, description string) Persona {
+	return Persona{
+		Description: description,
+		Profile: Profile{
+			Modifiers: &ModifierOverrides{
+				Words:        ptr(1.0),
+				Spaces:       &SpacesOverrides{Faces: ptr(0.0), Actions: ptr(0.0), Stutters: ptr(0.0)},
+				Exclamations: ptr(0.0),
+				Elongation:   &ElongationOverrides{Vowels: ptr(0.0), Tildes: ptr(0.0)},
+				Nya:          &NyaOverrides{Endings: ptr(0.0), Emphasis: ptr(0.0), Purrs: ptr(0.0), Mews: ptr(0.0)},
+			},
+			TriggerLimit: ptr(0),
+			Owoify:       ptr(level),
+		},
+	}
+}
+
+// ptr returns a pointer to value
+func ptr[T any](value T) *T { return &value }
+
+// WithPersona applies a persona to the uwuifier, see LookupPersona.
+// Unknown personas are ignored.
+func WithPersona(name string) Option {
+	return func(u *Uwuifier) {
+		u.SetPersona(name)
+	}
+}
+
+// WithPersonas adds personas the uwuifier can speak as, see SetPersonas
+func WithPersonas(personas map[string]Persona) Option {
+	return func(u *Uwuifier) {
+		u.SetPersonas(personas)
+	}
+}
+
+// Personas returns the names of the built-in personas
+func Personas() []string {
+	return slices.Sorted(maps.Keys(personas))
+}
+
+// LookupPersona returns a built-in persona: "default" changes nothing,
+// "catgirl" turns the catgirl stage on, "baby" talks like a baby and "shy"
+// stutters and trails off in ellipses. "owo", "uwu" and "uvu" speak like
+// the levels of the owoify library, see OwoifyLevel.
+func LookupPersona(name string) (Persona, error) {
+	persona, ok := personas[name]
+	if !ok {
+		return Persona{}, fmt.Errorf("unknown persona %q, want one of %s", name, strings.Join(Personas(), ", "))
+	}
+	return persona, nil
+}
+
+// Personas returns a copy of the personas added with SetPersonas
+func (u *Uwuifier) Personas() map[string]Persona { return maps.Clone(u.personas) }
+
+// SetPersona applies one of the personas of the uwuifier to it for good,
+// as opposed to UwuifyAs
+func (u *Uwuifier) SetPersona(name string) error {
+	persona, err := u.persona(name)
+	if err != nil {
+		return err
+	}
+
+	c := u.Config()
+	persona.Profile.applyTo(&c)
+	if err := c.apply(u, ""); err != nil {
+		return fmt.Errorf("persona %q: %w", name, err)
+	}
+	return nil
+}
+
+// SetPersonas adds personas the uwuifier can speak as, next to the built-in
+// ones. They replace built-in personas of the same name.
+func (u *Uwuifier) SetPersonas(personas map[string]Persona) error {
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(personas)) {
+		if err := personas[name].validate(u); err != nil {
+			errs = append(errs, fmt.Errorf("persona %q: %w", name, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	u.personas = maps.Clone(personas)
+	u.changed()
+	return nil
+}
+
+// UwuifyAs uwuifies a sentence with all transformations like
+// UwuifySentence, speaking as the named persona instead of the uwuifier
+// itself. The uwuifier is left as it is, so it can switch personas from one
+// call to the next.
+func (u *Uwuifier) UwuifyAs(persona, sentence string) (string, error) {
+	derived, err := u.derive(persona)
+	if err != nil {
+		return "", err
+	}
+	return derived.UwuifySentence(sentence), nil
+}
+
+// persona returns one of the personas of the uwuifier
+func (u *Uwuifier) persona(name string) (Persona, error) {
+	if persona, ok := u.personas[name]; ok {
+		return persona, nil
+	}
+	return LookupPersona(name)
+}
+
+// validate checks that the persona can be applied to u
+func (p Persona) validate(u *Uwuifier) error {
+	if p.Profile.Extends != "" {
+		return errors.New("personas cannot extend profiles")
+	}
+
+	c := u.Config()
+	c.Personas = nil
+	p.Profile.applyTo(&c)
+	return c.apply(New(), "")
+}
+
+// derive returns u speaking as the named persona, reusing the uwuifier
+// derived last time unless u changed since
+func (u *Uwuifier) derive(name string) (*Uwuifier, error) {
+	persona, err := u.persona(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.derived == nil {
+		return u.speakAs(name, persona)
+	}
+
+	d := u.derived
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !slices.Equal(d.faces, u.Faces) || !slices.Equal(d.actions, u.Actions) ||
+		!slices.Equal(d.exclamations, u.Exclamations) {
+		clear(d.personas)
+		d.faces = slices.Clone(u.Faces)
+		d.actions = slices.Clone(u.Actions)
+		d.exclamations = slices.Clone(u.Exclamations)
+	}
+	if derived, ok := d.personas[name]; ok {
+		return derived, nil
+	}
+
+	derived, err := u.speakAs(name, persona)
+	if err != nil {
+		return nil, err
+	}
+	d.personas[name] = derived
+	return derived, nil
+}
+
+// speakAs returns a new uwuifier configured like u with persona applied
+func (u *Uwuifier) speakAs(name string, persona Persona) (*Uwuifier, error) {
+	c := u.Config()
+	c.Personas = nil
+	persona.Profile.applyTo(&c)
+
+	derived := New(WithRandomSource(u.source))
+	if err := c.apply(derived, ""); err != nil {
+		return nil, fmt.Errorf("persona %q: %w", name, err)
+	}
+	return derived, nil
+}
+
+// derivedSet holds the uwuifiers derived from one for its personas
+type derivedSet struct {
+	mu       sync.Mutex
+	personas map[string]*Uwuifier
+
+	// Snapshots of the expression lists the personas were derived from
+	faces, actions, exclamations []string
+}
+
+// newDerivedSet creates an empty set of derived uwuifiers
+func newDerivedSet() *derivedSet {
+	return &derivedSet{personas: make(map[string]*Uwuifier)}
+}
+
+// reset drops every derived uwuifier
+func (d *derivedSet) reset() {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	clear(d.personas)
+}
@@ -0,0 +1,174 @@
+package gouwu
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBuiltinPersonas(t *testing.T) {
+	if names := Personas(); !slices.Equal(names, []string{"baby", "catgirl", "default", "owo", "shy", "uvu", "uwu"}) {
+		t.Errorf("Personas() = %v", names)
+	}
+
+	uwuifier := New()
+	for _, name := range Personas() {
+		persona, err := LookupPersona(name)
+		if err != nil {
+			t.Fatalf("LookupPersona(%q) error = %v", name, err)
+		}
+		if err := persona.validate(uwuifier); err != nil {
+			t.Errorf("persona %q is invalid: %v", name, err)
+		}
+	}
+
+	if _, err := LookupPersona("pirate"); err == nil || !strings.Contains(err.Error(), "baby, catgirl, default, owo, shy, uvu, uwu") {
+		t.Errorf("LookupPersona() error = %v, want the known personas", err)
+	}
+}
+
+func TestUwuifyAs(t *testing.T) {
+	uwuifier := New(WithWords(1), WithSpaces(SpacesModifier{}), WithExclamations(0))
+
+	testCases := []struct {
+		persona  string
+		input    string
+		expected string
+	}{
+		{"catgirl", "the new cat is perfect", "the mew cat is purrfect"},
+		{"baby", "thanks, that is little", "fanks, dat ish widdle"},
+		{"shy", "yes, hi", "yes... hi"},
+	}
+
+	for _, tc := range testCases {
+		result, err := uwuifier.UwuifyAs(tc.persona, tc.input)
+		if err != nil {
+			t.Fatalf("UwuifyAs(%q) error = %v", tc.persona, err)
+		}
+		if !strings.Contains(result, tc.expected) {
+			t.Errorf("UwuifyAs(%q, %q) = %q, want it to contain %q", tc.persona, tc.input, result, tc.expected)
+		}
+	}
+
+	expected := New(WithWords(1), WithSpaces(SpacesModifier{}), WithExclamations(0)).Config()
+	if config := uwuifier.Config(); !reflect.DeepEqual(config, expected) {
+		t.Errorf("UwuifyAs() changed the uwuifier to %+v", config)
+	}
+}
+
+func TestUwuifyAsDefaultMatchesSentence(t *testing.T) {
+	input := "Hello there, my friend! How are you doing today?"
+	result, err := New().UwuifyAs("default", input)
+	if err != nil {
+		t.Fatalf("UwuifyAs() error = %v", err)
+	}
+	if expected := New().UwuifySentence(input); result != expected {
+		t.Errorf("UwuifyAs(\"default\") = %q, want %q", result, expected)
+	}
+}
+
+func TestUwuifyAsFollowsChanges(t *testing.T) {
+	uwuifier := New(WithWords(0), WithSpaces(SpacesModifier{}), WithExclamations(0))
+
+	if result, _ := uwuifier.UwuifyAs("catgirl", "hello"); result != "hello" {
+		t.Errorf("UwuifyAs() = %q, want %q", result, "hello")
+	}
+
+	uwuifier.SetWordsModifier(1)
+	if result, _ := uwuifier.UwuifyAs("catgirl", "hello"); result != "hewwo" {
+		t.Errorf("UwuifyAs() after SetWordsModifier = %q, want %q", result, "hewwo")
+	}
+
+	// Lists are exported and can change without a setter
+	uwuifier.SetSpacesModifier(SpacesModifier{Faces: 1})
+	uwuifier.Faces = []string{"UwU"}
+	persona := Persona{Profile: Profile{}}
+	uwuifier.SetPersonas(map[string]Persona{"plain": persona})
+	if result, _ := uwuifier.UwuifyAs("plain", "hello world"); result != "hewwo UwU wowwd UwU" {
+		t.Errorf("UwuifyAs() = %q, want %q", result, "hewwo UwU wowwd UwU")
+	}
+	uwuifier.Faces = []string{"OwO"}
+	if result, _ := uwuifier.UwuifyAs("plain", "hello world"); result != "hewwo OwO wowwd OwO" {
+		t.Errorf("UwuifyAs() after changing faces = %q, want %q", result, "hewwo OwO wowwd OwO")
+	}
+}
+
+func TestSetPersonas(t *testing.T) {
+	pirate := Persona{
+		Description: "talks like a pirate",
+		Profile:     Profile{Dictionary: map[string]string{"hello": "ahoy"}},
+	}
+	uwuifier := New(WithWords(0), WithSpaces(SpacesModifier{}), WithExclamations(0),
+		WithPersonas(map[string]Persona{"pirate": pirate, "shy": pirate}))
+
+	for _, name := range []string{"pirate", "shy"} {
+		if result, err := uwuifier.UwuifyAs(name, "hello, world."); err != nil || result != "ahoy, world." {
+			t.Errorf("UwuifyAs(%q) = %q, %v, want %q", name, result, err, "ahoy, world.")
+		}
+	}
+	if _, err := uwuifier.UwuifyAs("ninja", "hello"); err == nil {
+		t.Error("UwuifyAs() of an unknown persona succeeded")
+	}
+
+	testCases := []struct {
+		name     string
+		persona  Persona
+		expected string
+	}{
+		{"extends", Persona{Profile: Profile{Extends: "shy"}}, "cannot extend"},
+		{"bad modifier", Persona{Profile: Profile{Modifiers: &ModifierOverrides{Words: ptr(2.0)}}}, "modifiers.words"},
+		{"bad rule", Persona{Profile: Profile{Rules: []Rule{{Pattern: "("}}}}, "rules"},
+	}
+
+	for _, tc := range testCases {
+		err := uwuifier.SetPersonas(map[string]Persona{tc.name: tc.persona})
+		if err == nil || !strings.Contains(err.Error(), tc.expected) || !strings.Contains(err.Error(), tc.name) {
+			t.Errorf("SetPersonas(%s) error = %v, want %q", tc.name, err, tc.expected)
+		}
+	}
+	if _, ok := uwuifier.Personas()["pirate"]; !ok {
+		t.Error("SetPersonas() with an error replaced the personas")
+	}
+}
+
+func TestSetPersona(t *testing.T) {
+	uwuifier := New(WithPersona("catgirl"))
+	if nya := uwuifier.NyaModifier(); nya.Purrs != 1 || nya.Mews != 1 {
+		t.Errorf("NyaModifier() = %+v, want the catgirl stage on", nya)
+	}
+	if !slices.Contains(uwuifier.Faces, "=^w^=") {
+		t.Errorf("Faces = %v, want the catgirl faces", uwuifier.Faces)
+	}
+
+	if err := uwuifier.SetPersona("pirate"); err == nil {
+		t.Error("SetPersona() of an unknown persona succeeded")
+	}
+}
+
+func TestPersonasConfigRoundTrip(t *testing.T) {
+	uwuifier := New(WithPersonas(map[string]Persona{
+		"pirate": {Profile: Profile{
+			Modifiers:  &ModifierOverrides{Nya: &NyaOverrides{Endings: ptr(0.5)}},
+			Dictionary: map[string]string{"hello": "ahoy"},
+		}},
+	}))
+
+	data, err := json.Marshal(uwuifier.Config())
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	config, err := ParseConfig(data)
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	if !reflect.DeepEqual(config, uwuifier.Config()) {
+		t.Errorf("ParseConfig() = %+v, want %+v", config, uwuifier.Config())
+	}
+
+	changes := DiffConfig(DefaultConfig(), config)
+	if len(changes) != 1 || changes[0].Field != `personas["pirate"]` {
+		t.Errorf("DiffConfig() = %v, want the pirate persona added", changes)
+	}
+}
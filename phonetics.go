@@ -0,0 +1,195 @@
+package gouwu
+
+import (
+	"regexp"
+	"slices"
+)
+
+// Phonetics turns the rules of the phonetic pack on and off. Every rule
+// keeps the case of the word, so "The" becomes "Da" and "THE" becomes "DA".
+type Phonetics struct {
+	// InitialTh turns "th" starting a word into "d", like "this" to "dis"
+	// and "the" to "da"
+	InitialTh bool `json:"initial_th"`
+
+	// MedialTh turns any other "th" into "f", like "nothing" to "nofing"
+	MedialTh bool `json:"medial_th"`
+
+	// Ing drops the g of "-ing", like "going" to "goin'"
+	Ing bool `json:"ing"`
+
+	// Diminutives turn "-y" after a doubled consonant into "-ie", like
+	// "doggy" to "doggie"
+	Diminutives bool `json:"diminutives"`
+
+	// You turns "you" into "yuw"
+	You bool `json:"you"`
+}
+
+// AllPhonetics turns every rule of the phonetic pack on
+var AllPhonetics = Phonetics{InitialTh: true, MedialTh: true, Ing: true, Diminutives: true, You: true}
+
+// WithPhonetics turns rules of the phonetic pack on, see SetPhonetics
+func WithPhonetics(phonetics Phonetics) Option {
+	return func(u *Uwuifier) {
+		u.SetPhonetics(phonetics)
+	}
+}
+
+// Phonetics returns the rules of the phonetic pack that are on
+func (u *Uwuifier) Phonetics() Phonetics { return u.phonetics }
+
+// SetPhonetics turns rules of the phonetic pack on and off. They run after
+// the built-in word rules and before custom ones, each with the words
+// modifier as probability. Every rule is off by default.
+func (u *Uwuifier) SetPhonetics(phonetics Phonetics) {
+	u.phonetics = phonetics
+	u.phoneticRules = phonetics.rules()
+	u.changed()
+}
+
+// rules returns the replacements of the rules that are on
+func (p Phonetics) rules() []UwuReplacement {
+	var rules []UwuReplacement
+	if p.InitialTh {
+		rules = append(rules, phoneticRule(`^th`, "d", initialTh))
+	}
+	if p.MedialTh {
+		rules = append(rules, phoneticRule(`.th`, "f", medialTh))
+	}
+	if p.Ing {
+		rules = append(rules, phoneticRule(`ing$`, "in'", ing))
+	}
+	if p.Diminutives {
+		rules = append(rules, phoneticRule(`[b-df-hj-np-tv-z]{2}y$`, "ie", diminutive))
+	}
+	if p.You {
+		rules = append(rules, phoneticRule(`^you$`, "yuw", you))
+	}
+	return slices.Clip(rules)
+}
+
+// phoneticRule builds a replacement that applies rewrite to the core of a
+// word, without the punctuation around it. The pattern and replacement only
+// describe the rule, ignoring case.
+func phoneticRule(pattern, replacement string, rewrite func(dst, core []byte) ([]byte, bool)) UwuReplacement {
+	return UwuReplacement{
+		Pattern:     regexp.MustCompile(pattern),
+		Replacement: replacement,
+		replace: func(dst, src []byte) []byte {
+			start, end := wordCore(src)
+			n := len(dst)
+			dst = append(dst, src[:start]...)
+			dst, ok := rewrite(dst, src[start:end])
+			if !ok {
+				return append(dst[:n], src...)
+			}
+			return append(dst, src[end:]...)
+		},
+	}
+}
+
+// initialTh rewrites a core starting with "th", with "the" becoming "da"
+func initialTh(dst, core []byte) ([]byte, bool) {
+	if !hasPrefixFold(core, "th") {
+		return dst, false
+	}
+	if len(core) == 3 && core[2]|0x20 == 'e' {
+		return appendCased(dst, core, core[:2], "da"), true
+	}
+	dst = appendCased(dst, core, core[:2], "d")
+	return append(dst, core[2:]...), true
+}
+
+// medialTh rewrites every "th" of a core but a leading one into "f"
+func medialTh(dst, core []byte) ([]byte, bool) {
+	found := false
+	for i := 0; i < len(core); i++ {
+		if i > 0 && i+1 < len(core) && hasPrefixFold(core[i:], "th") {
+			dst = appendCased(dst, core, core[i:i+2], "f")
+			found = true
+			i++
+			continue
+		}
+		dst = append(dst, core[i])
+	}
+	return dst, found
+}
+
+// ing rewrites a core ending in "ing" after a vowel, so "going" becomes
+// "goin'" but "thing" stays
+func ing(dst, core []byte) ([]byte, bool) {
+	n := len(core) - 3
+	if n < 1 || !hasPrefixFold(core[n:], "ing") || !slices.ContainsFunc(core[:n], isVowelOrY) {
+		return dst, false
+	}
+	dst = append(dst, core[:n]...)
+	return appendCased(dst, core, core[n:], "in'"), true
+}
+
+// diminutive rewrites a core ending in a doubled consonant and "y"
+func diminutive(dst, core []byte) ([]byte, bool) {
+	n := len(core) - 1
+	if n < 3 || core[n]|0x20 != 'y' || core[n-1]|0x20 != core[n-2]|0x20 ||
+		!isLetter(core[n-1]) || isVowelOrY(core[n-1]) {
+		return dst, false
+	}
+	dst = append(dst, core[:n]...)
+	return appendCased(dst, core, core[n:], "ie"), true
+}
+
+// you rewrites a core that is "you"
+func you(dst, core []byte) ([]byte, bool) {
+	if len(core) != 3 || !hasPrefixFold(core, "you") {
+		return dst, false
+	}
+	return appendCased(dst, core, core, "yuw"), true
+}
+
+// appendCased appends the lowercase replacement of part of core to dst,
+// uppercased if the whole core is or capitalized if part starts capitalized
+func appendCased(dst, core, part []byte, replacement string) []byte {
+	switch {
+	case len(core) > 1 && isAllUpper(core):
+		for i := range len(replacement) {
+			dst = append(dst, toUpper(replacement[i]))
+		}
+	case isUpper(part[0]):
+		dst = append(dst, toUpper(replacement[0]))
+		dst = append(dst, replacement[1:]...)
+	default:
+		dst = append(dst, replacement...)
+	}
+	return dst
+}
+
+// isAllUpper reports whether word has letters and all of them are ASCII
+// capitals
+func isAllUpper(word []byte) bool {
+	letters := false
+	for _, c := range word {
+		if c >= 'a' && c <= 'z' {
+			return false
+		}
+		letters = letters || isUpper(c)
+	}
+	return letters
+}
+
+// isVowelOrY reports whether c is an ASCII vowel or y
+func isVowelOrY(c byte) bool {
+	return isVowel(c) || c|0x20 == 'y'
+}
+
+// isLetter reports whether c is an ASCII letter
+func isLetter(c byte) bool {
+	return c|0x20 >= 'a' && c|0x20 <= 'z'
+}
+
+// toUpper returns the capital of an ASCII lowercase letter, and c otherwise
+func toUpper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 0x20
+	}
+	return c
+}
@@ -0,0 +1,74 @@
+package gouwu
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPhonetics(t *testing.T) {
+	testCases := []struct {
+		name      string
+		phonetics Phonetics
+		input     string
+		expected  string
+	}{
+		{"initial th", Phonetics{InitialTh: true}, "the this That THE THIS (thanks)", "da dis Dat DA DIS (danks)"},
+		{"initial th only", Phonetics{InitialTh: true}, "nothing", "nothing"},
+		{"medial th", Phonetics{MedialTh: true}, "nothing with BATH Mother the", "nofing wif BAF Mofer the"},
+		{"ing", Phonetics{Ing: true}, "going, SAYING Walking thing king ping-pong", "goin', SAYIN' Walkin' thing king ping-pong"},
+		{"diminutives", Phonetics{Diminutives: true}, "doggy KITTY Puppy! very silly buggy", "doggie KITTIE Puppie! very sillie buggie"},
+		{"you", Phonetics{You: true}, "you You YOU? your", "yuw Yuw YUW? your"},
+		{"all", AllPhonetics, "Thank you for nothing", "Dank yuw for nofin'"},
+		{"off", Phonetics{}, "thank you for nothing", "thank you for nothing"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uwuifier := New(WithWords(1), WithPhonetics(tc.phonetics))
+			// Without the built-in rules, which run first
+			uwuifier.uwuMap = nil
+			if result := uwuifier.UwuifyWords(tc.input); result != tc.expected {
+				t.Errorf("UwuifyWords(%q) = %q, want %q", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestPhoneticsWithBuiltinRules(t *testing.T) {
+	uwuifier := New(WithWords(1), WithPhonetics(AllPhonetics))
+	if result := uwuifier.UwuifyWords("the little puppy loves nothing"); result != "da wittwe puppie wuvs nyofin'" {
+		t.Errorf("UwuifyWords() = %q, want %q", result, "da wittwe puppie wuvs nyofin'")
+	}
+}
+
+func TestPhoneticsConfig(t *testing.T) {
+	uwuifier := New(WithPhonetics(Phonetics{Ing: true, You: true}))
+	if phonetics := uwuifier.Phonetics(); phonetics != (Phonetics{Ing: true, You: true}) {
+		t.Errorf("Phonetics() = %+v", phonetics)
+	}
+
+	loaded, err := NewFromConfig(uwuifier.Config())
+	if err != nil {
+		t.Fatalf("NewFromConfig() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Config(), uwuifier.Config()) {
+		t.Errorf("Config() = %+v, want %+v", loaded.Config(), uwuifier.Config())
+	}
+
+	config, err := ParseConfig([]byte(`{"profiles": {"drawl": {"phonetics": {"ing": false, "initial_th": true}}}}`))
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	config.Phonetics = uwuifier.Phonetics()
+	changes, err := config.ProfileDiff("drawl")
+	if err != nil {
+		t.Fatalf("ProfileDiff() error = %v", err)
+	}
+	expected := []Change{
+		{Field: "phonetics.initial_th", From: "false", To: "true"},
+		{Field: "phonetics.ing", From: "true", To: "false"},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("ProfileDiff() = %v, want %v", changes, expected)
+	}
+}
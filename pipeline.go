@@ -0,0 +1,361 @@
+package gouwu
+
+import (
+	"bytes"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// stage selects which transformations a pass over a sentence applies
+type stage uint8
+
+const (
+	stageWords stage = 1 << iota
+	stageNya
+	stageElongation
+	stageExclamations
+	stageSpaces
+
+	stageAll = stageWords | stageNya | stageElongation | stageExclamations | stageSpaces
+)
+
+// scratch holds the buffers a single pass reuses between words. tail holds
+// the words a stage adds after the current one, each after a space.
+type scratch struct {
+	word, tail, spare, key, prev, fold, out []byte
+}
+
+var scratchPool = sync.Pool{
+	New: func() any { return new(scratch) },
+}
+
+// AppendUwuify appends the uwuified form of src to dst and returns the
+// extended buffer. It produces the same text as UwuifySentence but tokenizes
+// src once and reuses its internal buffers, so it does not allocate once dst
+// has enough capacity.
+func (u *Uwuifier) AppendUwuify(dst []byte, src []byte) []byte {
+	return appendUwuify(u, dst, src, stageAll)
+}
+
+// uwuifyString runs the selected stages over sentence and returns the result
+func (u *Uwuifier) uwuifyString(sentence string, stages stage) string {
+	sc := scratchPool.Get().(*scratch)
+	sc.out = appendUwuify(u, sc.out[:0], sentence, stages)
+	result := string(sc.out)
+	scratchPool.Put(sc)
+	return result
+}
+
+// appendUwuify splits src on spaces once and runs every selected stage over
+// each word before moving on to the next. This is equivalent to running the
+// stages one after another over the whole sentence as long as the spaces
+// stage treats the words earlier stages put in a word, like a dictionary
+// entry or exclamation containing spaces, as words of their own.
+func appendUwuify[T text](u *Uwuifier, dst []byte, src T, stages stage) []byte {
+	if u.parity {
+		return appendUpstream(u, dst, src, stages)
+	}
+
+	sc := scratchPool.Get().(*scratch)
+	defer scratchPool.Put(sc)
+
+	// Faces and actions depend on the mood of the whole sentence
+	var mood Mood
+	if stages&stageSpaces != 0 && len(u.lexicon) > 0 {
+		mood = detectMood(u.lexicon, sc, src)
+	}
+
+	// Only whole sentences are cached, only with the default source, only
+	// without a mood and only as long as the expression lists stay the same
+	cache := u.cache
+	if stages != stageAll || u.source != nil || mood != "" {
+		cache = nil
+	}
+	if cache != nil {
+		cache.validate(u)
+	}
+
+	// The capitalization check looks at the previous word as it was written
+	prevStart, prevEnd := 0, 0
+
+	// Triggered actions are capped per sentence
+	triggered := 0
+
+	// Whether the next word starts a sentence, for sentence case
+	sentenceStart := true
+
+	for i, start := 0, 0; start <= len(src); i++ {
+		end := start
+		for end < len(src) && src[end] != ' ' {
+			end++
+		}
+
+		if i > 0 {
+			dst = append(dst, ' ')
+		}
+		wordStart := len(dst)
+
+		sc.word = append(sc.word[:0], src[start:end]...)
+		sc.tail = sc.tail[:0]
+
+		// Sentences end in '.', '!' or '?' as they were given
+		startsSentence := sentenceStart
+		if u.capitalization == CapitalizationSentence && len(sc.word) > 0 {
+			sentenceStart = endsWithSentence(sc.word)
+		}
+
+		// Keywords match the word as it was given, and whether its trigger
+		// fires depends on the sentence, so such words skip the cache
+		trigger := -1
+		if stages&stageSpaces != 0 && triggered < u.triggerLimit {
+			trigger = u.triggerFor(sc)
+		}
+
+		// The last word may get a tilde or cat sound for ending the text,
+		// so it skips the cache too
+		last := end == len(src)
+		uncached := trigger >= 0 || last && (u.elongationModifier.Tildes > 0 || u.nyaModifier.Endings > 0 ||
+			u.nyaModifier.Emphasis > 0)
+
+		protected := u.isProtected(sc)
+
+		var capitalize, cached bool
+		if cache != nil && !uncached {
+			dst, capitalize, cached = cache.appendWord(dst, sc.word)
+		}
+		if !cached {
+			if cache != nil {
+				sc.key = append(sc.key[:0], sc.word...)
+			}
+			if u.capitalization >= CapitalizationLower && !protected {
+				lowerWord(sc)
+			}
+
+			// Purrs and mews are left alone by the word rules
+			var purred bool
+			if stages&stageNya != 0 && !protected {
+				purred = u.nyaWord(sc)
+			}
+			if stages&stageWords != 0 && !protected && !purred {
+				u.uwuifyWord(sc)
+			}
+			if stages&stageElongation != 0 && !protected {
+				u.elongateWord(sc, last)
+			}
+			if stages&stageExclamations != 0 && !protected {
+				u.uwuifyExclamation(sc)
+			}
+			if stages&stageNya != 0 && !protected {
+				u.nyaEnd(sc, last)
+			}
+
+			var fired bool
+			if trigger >= 0 {
+				dst, fired = u.appendTriggered(dst, sc.word, sc.tail, trigger)
+			}
+			switch {
+			case fired:
+				triggered++
+				capitalize = !protected
+			case stages&stageSpaces != 0:
+				dst, capitalize = u.appendSpacedWords(dst, sc.word, sc.tail, protected, mood)
+			default:
+				dst = append(dst, sc.word...)
+				dst = append(dst, sc.tail...)
+			}
+			if cache != nil && !uncached {
+				cache.store(sc.key, dst[wordStart:], capitalize)
+			}
+		}
+
+		switch u.capitalization {
+		case CapitalizationLegacy:
+			if !capitalize {
+				break
+			}
+			// AlgorithmV1 reads the first byte as a Latin-1 rune like the
+			// original port, later versions decode the first rune
+			first, size := rune(dst[wordStart]), 1
+			if u.algorithm != AlgorithmV1 {
+				first, size = utf8.DecodeRune(dst[wordStart:])
+			}
+			if lowerFirst(dst[wordStart:], first, i, dst[prevStart:prevEnd]) &&
+				(!u.asciiOnly || u.algorithm != AlgorithmV1 || first < utf8.RuneSelf) {
+				sc.spare = append(sc.spare[:0], dst[wordStart+size:]...)
+				dst = utf8.AppendRune(dst[:wordStart], unicode.ToLower(first))
+				dst = append(dst, sc.spare...)
+			}
+		case CapitalizationSentence:
+			if startsSentence && !protected {
+				dst = capitalizeFirst(sc, dst, wordStart)
+			}
+		}
+
+		prevStart, prevEnd = wordStart, len(dst)
+		start = end + 1
+	}
+
+	return dst
+}
+
+// uwuifyWord applies the uwu replacement rules to sc.word
+func (u *Uwuifier) uwuifyWord(sc *scratch) {
+	if isAt(sc.word) || isURI(sc.word) {
+		return
+	}
+
+	if u.replaceFromDictionary(sc) {
+		return
+	}
+
+	r := u.randFor(sc.word)
+
+	// The phonetic pack runs after the built-in rules, and custom rules last
+	for _, rules := range [3][]UwuReplacement{u.wordRules(), u.phoneticRules, u.rules} {
+		for _, replacement := range rules {
+			// Generate random value for each pattern
+			randVal := r.float()
+			if randVal > u.wordsModifier {
+				continue
+			}
+			if len(replacement.choices) > 0 {
+				// Every match gets a choice of its own
+				sc.spare = replacement.applyChoices(sc.spare[:0], sc.word, &r, u.asciiOnly)
+				sc.word, sc.spare = sc.spare, sc.word
+				continue
+			}
+			if u.asciiOnly && !isASCII(replacement.Replacement) {
+				continue
+			}
+
+			sc.spare = replacement.apply(sc.spare[:0], sc.word)
+			sc.word, sc.spare = sc.spare, sc.word
+		}
+	}
+}
+
+// trailingExclamation returns the length of the run of '?' and '!' that word ends with
+func trailingExclamation(word []byte) int {
+	n := 0
+	for n < len(word) && (word[len(word)-1-n] == '?' || word[len(word)-1-n] == '!') {
+		n++
+	}
+	return n
+}
+
+// uwuifyExclamation replaces the exclamation ending sc.word with a more expressive one
+func (u *Uwuifier) uwuifyExclamation(sc *scratch) {
+	r := u.randFor(sc.word)
+	randVal := r.float()
+
+	n := trailingExclamation(sc.word)
+	if n == 0 || randVal > u.exclamationsModifier || isBreak(sc.word) ||
+		!u.exclamationWeights.available(u.Exclamations, u.filter()) {
+		return
+	}
+
+	exclamation := u.exclamationWeights.pick(&r, u.Exclamations, u.algorithm == AlgorithmV1, u.filter())
+	sc.word = append(sc.word[:len(sc.word)-n], exclamation...)
+}
+
+// appendSpacedWords appends word and the words of tail to dst like
+// appendSpaced, treating the words earlier stages put in word or after it as
+// words of their own. It reports whether the first letter of word may need
+// lowering.
+func (u *Uwuifier) appendSpacedWords(dst, word, tail []byte, protected bool, mood Mood) ([]byte, bool) {
+	var capitalize bool
+	if i := bytes.IndexByte(word, ' '); i >= 0 {
+		dst, capitalize = u.appendSpaced(dst, word[:i], protected, mood)
+		dst = append(dst, ' ')
+		dst, _ = u.appendSpacedWords(dst, word[i+1:], tail, protected, mood)
+		return dst, capitalize
+	}
+
+	dst, capitalize = u.appendSpaced(dst, word, protected, mood)
+	if len(tail) > 0 {
+		dst = append(dst, ' ')
+		dst, _ = u.appendSpacedWords(dst, tail[1:], nil, protected, mood)
+	}
+	return dst, capitalize
+}
+
+// appendSpaced appends word to dst, adding a face, action or stutter. It
+// reports whether a face or action was added, in which case the first letter
+// of the word may need lowering depending on the words around it. Protected
+// words are neither stuttered nor lowered, faces and actions suiting mood
+// are preferred, and faces are written as the accessibility mode says.
+func (u *Uwuifier) appendSpaced(dst, word []byte, protected bool, mood Mood) ([]byte, bool) {
+	if len(word) == 0 {
+		return dst, false
+	}
+
+	faceThreshold := u.spacesModifier.Faces
+	actionThreshold := u.spacesModifier.Actions + faceThreshold
+	stutterThreshold := u.spacesModifier.Stutters + actionThreshold
+
+	r := u.randFor(word)
+	randVal := r.float()
+	f := u.filter()
+	faces := f.preferring(mood, u.Faces, u.faceWeights)
+	actions := f.preferring(mood, u.Actions, u.actionWeights)
+
+	var insert string
+	var face bool
+	switch {
+	case randVal <= faceThreshold && u.faceWeights.available(u.Faces, faces) && !isBreak(word):
+		// Add random face
+		if u.accessibility == AccessibilityOmit {
+			return append(dst, word...), false
+		}
+		insert = u.faceWeights.pick(&r, u.Faces, u.algorithm == AlgorithmV1, faces)
+		face = true
+	case randVal <= actionThreshold && u.actionWeights.available(u.Actions, actions) && !isBreak(word):
+		// Add random action
+		insert = u.actionWeights.pick(&r, u.Actions, u.algorithm == AlgorithmV1, actions)
+	case randVal <= stutterThreshold && !isURI(word) && !isBreak(word) && !protected &&
+		u.canStutter(word) && u.accessibility == 0:
+		// Add stutter
+		return u.appendStutter(dst, word, &r), false
+	default:
+		return append(dst, word...), false
+	}
+
+	dst = append(dst, word...)
+	dst = append(dst, ' ')
+	if face {
+		dst = u.appendFace(dst, insert)
+	} else {
+		dst = append(dst, insert...)
+	}
+	return dst, !protected
+}
+
+// lowerFirst reports whether the capital starting word should be lowered
+// after a face or action was added to it. first is the rune word starts
+// with, index is the position of the word in the sentence and prev is the
+// previous word as it was written.
+func lowerFirst(word []byte, firstChar rune, index int, prev []byte) bool {
+	// Check if we should remove the first capital letter
+	if unicode.ToUpper(firstChar) != firstChar {
+		return false
+	}
+	// If word has higher than 50% upper case
+	if getCapitalPercentage(word) > 0.5 {
+		return false
+	}
+
+	// If it's the first word
+	if index == 0 {
+		return true
+	}
+
+	if len(prev) == 0 {
+		return false
+	}
+	switch prev[len(prev)-1] {
+	case '.', '!', '?', '-':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,342 @@
+package gouwu
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// goldenCase is one line of a testdata/golden_v*.txt file. Inputs and
+// outputs are Go-quoted since the original port can emit invalid UTF-8.
+type goldenCase struct {
+	Config string
+	Stage  string
+	Input  string
+	Output string
+}
+
+// readGolden parses a golden file of tab separated cases
+func readGolden(t *testing.T, path string) []goldenCase {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+	defer f.Close()
+
+	var cases []goldenCase
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.HasPrefix(scanner.Text(), "#") {
+			continue
+		}
+
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) != 4 {
+			t.Fatalf("%s:%d: want 4 fields, got %d", path, line, len(fields))
+		}
+
+		input, err := strconv.Unquote(fields[2])
+		if err != nil {
+			t.Fatalf("%s:%d: input: %v", path, line, err)
+		}
+		output, err := strconv.Unquote(fields[3])
+		if err != nil {
+			t.Fatalf("%s:%d: output: %v", path, line, err)
+		}
+
+		cases = append(cases, goldenCase{fields[0], fields[1], input, output})
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+
+	return cases
+}
+
+// goldenUwuifier builds the uwuifier a golden case was recorded with
+func goldenUwuifier(t *testing.T, name string, version AlgorithmVersion) *Uwuifier {
+	switch name {
+	case "default":
+		return New(WithAlgorithmVersion(version))
+	case "words":
+		return New(WithAlgorithmVersion(version), WithWords(1))
+	case "spaces":
+		return New(WithAlgorithmVersion(version), WithSpaces(SpacesModifier{Faces: 0.3, Actions: 0.3, Stutters: 0.4}))
+	case "exclamations":
+		return New(WithAlgorithmVersion(version), WithExclamations(1), WithWords(0.5))
+	}
+	t.Fatalf("unknown golden config %q", name)
+	return nil
+}
+
+// TestGoldenOutput holds every algorithm version to the output it was
+// released with. The golden files of released versions must never be
+// regenerated; behaviour changes belong in a new version. Output that was
+// not valid UTF-8 is the only exception.
+func TestGoldenOutput(t *testing.T) {
+	for version := AlgorithmV1; version <= LatestAlgorithmVersion; version++ {
+		path := fmt.Sprintf("testdata/golden_v%d.txt", version)
+		for _, tc := range readGolden(t, path) {
+			checkGolden(t, goldenUwuifier(t, tc.Config, version), tc)
+		}
+	}
+}
+
+// checkGolden runs the stage a golden case was recorded with
+func checkGolden(t *testing.T, uwuifier *Uwuifier, tc goldenCase) {
+	t.Helper()
+
+	var result string
+	switch tc.Stage {
+	case "sentence":
+		result = uwuifier.UwuifySentence(tc.Input)
+	case "words":
+		result = uwuifier.UwuifyWords(tc.Input)
+	case "exclamations":
+		result = uwuifier.UwuifyExclamations(tc.Input)
+	case "spaces":
+		result = uwuifier.UwuifySpaces(tc.Input)
+	default:
+		t.Fatalf("unknown golden stage %q", tc.Stage)
+	}
+
+	if result != tc.Output {
+		t.Errorf("v%d %s/%s(%q) = %q, want %q", uwuifier.AlgorithmVersion(),
+			tc.Config, tc.Stage, tc.Input, result, tc.Output)
+	}
+}
+
+func TestAppendUwuify(t *testing.T) {
+	uwuifier := New(WithSpaces(SpacesModifier{Faces: 0.3, Actions: 0.3, Stutters: 0.4}))
+
+	testCases := []string{
+		"",
+		" ",
+		"Hello world!",
+		"  leading and  double  spaces  ",
+		"Visit https://github.com/user/repo for more info",
+		"Émile went to the store. Élodie followed.",
+	}
+
+	for _, input := range testCases {
+		t.Run(input, func(t *testing.T) {
+			prefix := []byte("prefix: ")
+			result := uwuifier.AppendUwuify(prefix, []byte(input))
+			expected := "prefix: " + uwuifier.UwuifySentence(input)
+
+			if string(result) != expected {
+				t.Errorf("AppendUwuify(%q) = %q, want %q", input, result, expected)
+			}
+		})
+	}
+}
+
+func TestInsertedWords(t *testing.T) {
+	uwuifier := New(
+		WithSpaces(SpacesModifier{Faces: 0.3, Actions: 0.3, Stutters: 0.4}),
+		WithExclamationList("! ✨", "?! 😳"),
+		WithDictionary(map[string]string{"brb": "be right back"}),
+	)
+
+	testCases := []string{
+		"hello world! how are you?",
+		"brb, getting snacks! wait for me?!",
+		"wow! wow! wow!",
+	}
+
+	// Words the earlier stages insert are spaced as if the stages ran one
+	// after another
+	for _, input := range testCases {
+		expected := uwuifier.UwuifySpaces(uwuifier.UwuifyExclamations(uwuifier.UwuifyWords(input)))
+		if result := uwuifier.UwuifySentence(input); result != expected {
+			t.Errorf("UwuifySentence(%q) = %q, want %q", input, result, expected)
+		}
+	}
+}
+
+func TestAppendUwuifyAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops items at random under the race detector")
+	}
+
+	uwuifier := New()
+	src := []byte("Hello world! This is a test sentence with https://example.com and @someone, really?!")
+	dst := make([]byte, 0, 1024)
+
+	// Warm up the scratch buffers
+	uwuifier.AppendUwuify(dst, src)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		uwuifier.AppendUwuify(dst[:0], src)
+	})
+	if allocs > 0 {
+		t.Errorf("AppendUwuify allocated %v times per run, want 0", allocs)
+	}
+}
+
+func BenchmarkAppendUwuify(b *testing.B) {
+	uwuifier := New()
+	src := []byte("Hello world! This is a test sentence with https://example.com and @someone, really?!")
+	dst := make([]byte, 0, 1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst = uwuifier.AppendUwuify(dst[:0], src)
+	}
+}
+
+// referenceUwuify is the implementation the pipeline replaced: every stage
+// splits the sentence again and compiles its regular expressions per call
+func referenceUwuify(u *Uwuifier, sentence string) string {
+	uwuMap := []UwuReplacement{
+		{Pattern: regexp.MustCompile(`ove`), Replacement: "uv"},
+		{Pattern: regexp.MustCompile(`[rl]`), Replacement: "w"},
+		{Pattern: regexp.MustCompile(`[RL]`), Replacement: "W"},
+		{Pattern: regexp.MustCompile(`n([aeiou])`), Replacement: "ny$1"},
+		{Pattern: regexp.MustCompile(`N([aeiou])`), Replacement: "Ny$1"},
+		{Pattern: regexp.MustCompile(`N([AEIOU])`), Replacement: "NY$1"},
+	}
+
+	words := strings.Split(sentence, " ")
+	for i, word := range words {
+		if strings.HasPrefix(word, "@") || referenceIsURI(word) {
+			continue
+		}
+		seed := NewSeed(word)
+		for _, replacement := range uwuMap {
+			if seed.Float64() > u.wordsModifier {
+				continue
+			}
+			word = replacement.Pattern.ReplaceAllString(word, replacement.Replacement)
+		}
+		words[i] = word
+	}
+
+	exclamation := regexp.MustCompile(`[?!]+$`)
+	for i, word := range words {
+		seed := NewSeed(word)
+		if !exclamation.MatchString(word) || seed.Float64() > u.exclamationsModifier ||
+			strings.TrimSpace(word) == "" {
+			continue
+		}
+		word = exclamation.ReplaceAllString(word, "")
+		words[i] = word + u.Exclamations[roundedInt(seed.Float64(), 0, len(u.Exclamations)-1)]
+	}
+
+	faceThreshold := u.spacesModifier.Faces
+	actionThreshold := u.spacesModifier.Actions + faceThreshold
+	stutterThreshold := u.spacesModifier.Stutters + actionThreshold
+	for i, word := range words {
+		if word == "" {
+			continue
+		}
+		seed := NewSeed(word)
+		randVal := seed.Float64()
+		firstChar := string(rune(word[0]))
+
+		checkCapital := func() {
+			if firstChar != strings.ToUpper(firstChar) || getCapitalPercentage(word) > 0.5 {
+				return
+			}
+			if i == 0 || len(words[i-1]) > 0 &&
+				regexp.MustCompile(`[.!?\-]`).MatchString(words[i-1][len(words[i-1])-1:]) {
+				word = strings.ToLower(firstChar) + word[1:]
+			}
+		}
+
+		switch {
+		case randVal <= faceThreshold && strings.TrimSpace(word) != "":
+			word += " " + u.Faces[roundedInt(seed.Float64(), 0, len(u.Faces)-1)]
+			checkCapital()
+		case randVal <= actionThreshold && strings.TrimSpace(word) != "":
+			word += " " + u.Actions[roundedInt(seed.Float64(), 0, len(u.Actions)-1)]
+			checkCapital()
+		case randVal <= stutterThreshold && !referenceIsURI(word) && strings.TrimSpace(word) != "":
+			word = strings.Repeat(firstChar+"-", roundedInt(seed.Float64(), 0, 2)) + word
+		}
+		words[i] = word
+	}
+
+	return strings.Join(words, " ")
+}
+
+// referenceIsURI is the regular expression based URI check the pipeline
+// replaced
+func referenceIsURI(value string) bool {
+	if value == "" ||
+		regexp.MustCompile(`[^a-zA-Z0-9:/?#\[\]@!$&'()*+,;=.\-_~%]`).MatchString(value) ||
+		regexp.MustCompile(`%[^0-9a-fA-F]`).MatchString(value) ||
+		regexp.MustCompile(`%[0-9a-fA-F]([^0-9a-fA-F]|$)`).MatchString(value) {
+		return false
+	}
+
+	matches := regexp.MustCompile(`(?:([^:/?#]+):)?(?://([^/?#]*))?([^?#]*)(?:\?([^#]*))?(?:#(.*))?`).
+		FindStringSubmatch(value)
+	scheme, authority, path := matches[1], matches[2], matches[3]
+	switch {
+	case scheme == "":
+		return false
+	case authority != "" && path != "" && !strings.HasPrefix(path, "/"):
+		return false
+	case authority == "" && strings.HasPrefix(path, "//"):
+		return false
+	}
+	return regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+\-.]*$`).MatchString(scheme)
+}
+
+// BenchmarkUwuifyImplementations compares the pipeline with the
+// implementation it replaced on the same sentence and output
+func BenchmarkUwuifyImplementations(b *testing.B) {
+	uwuifier := New(WithAlgorithmVersion(AlgorithmV1))
+	sentence := "Hello world! This is a test sentence with https://example.com and @someone, really?!"
+	if reference, result := referenceUwuify(uwuifier, sentence), uwuifier.UwuifySentence(sentence); reference != result {
+		b.Fatalf("reference output %q differs from %q", reference, result)
+	}
+
+	b.Run("reference", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			referenceUwuify(uwuifier, sentence)
+		}
+	})
+	b.Run("pipeline", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			uwuifier.UwuifySentence(sentence)
+		}
+	})
+}
+
+func TestFaceSelectionEndpoints(t *testing.T) {
+	counts := func(version AlgorithmVersion) []int {
+		uwuifier := New(
+			WithAlgorithmVersion(version),
+			WithWords(0),
+			WithSpaces(SpacesModifier{Faces: 1}),
+			WithFaces("a", "b", "c"),
+		)
+
+		counts := make([]int, 3)
+		for i := 0; i < 3000; i++ {
+			result := uwuifier.UwuifySpaces(fmt.Sprintf("word%d", i))
+			counts[result[len(result)-1]-'a']++
+		}
+		return counts
+	}
+
+	// The original port picks the first and last face half as often
+	if legacy := counts(AlgorithmV1); legacy[0]*3/2 > legacy[1] || legacy[2]*3/2 > legacy[1] {
+		t.Errorf("algorithm 1 picked faces %v, want the middle one about twice as often", legacy)
+	}
+
+	for i, count := range counts(AlgorithmV2) {
+		if count < 900 || count > 1100 {
+			t.Errorf("algorithm 2 picked face %d %d times out of 3000, want about 1000", i, count)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package gouwu
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// presets adjust DefaultConfig into the built-in configurations, setting
+// only the fields each of them owns
+var presets = map[string]func(c *Config){
+	"default": func(c *Config) {},
+	"subtle": func(c *Config) {
+		c.Modifiers.Words = 0.5
+		c.Modifiers.Spaces = SpacesModifier{Faces: 0.02, Actions: 0, Stutters: 0.05}
+		c.Modifiers.Exclamations = 0.5
+	},
+	"sfw": func(c *Config) {
+		c.Rating = RatingSafe
+	},
+	"chaotic": func(c *Config) {
+		c.Modifiers.Words = 1
+		c.Modifiers.Spaces = SpacesModifier{Faces: 0.2, Actions: 0.2, Stutters: 0.3}
+		c.Modifiers.Exclamations = 1
+		c.Modifiers.Elongation = ElongationModifier{Vowels: 0.3, Tildes: 0.5}
+	},
+}
+
+// WithPreset applies the settings a built-in configuration changes, see
+// PresetConfig, and keeps every other setting of the uwuifier, so it can
+// come before or after other options. Unknown presets are ignored.
+func WithPreset(name string) Option {
+	return func(u *Uwuifier) {
+		preset, ok := presets[name]
+		if !ok {
+			return
+		}
+
+		c := u.Config()
+		preset(&c)
+		c.apply(u, "")
+	}
+}
+
+// Presets returns the names of the built-in configurations
+func Presets() []string {
+	return slices.Sorted(maps.Keys(presets))
+}
+
+// PresetConfig returns a built-in configuration: "default" is New() as is,
+// "subtle" uwuifies lightly and without actions, "sfw" only picks safe
+// expressions, and "chaotic" turns every stage up.
+func PresetConfig(name string) (Config, error) {
+	preset, ok := presets[name]
+	if !ok {
+		return Config{}, fmt.Errorf("unknown preset %q, want one of %s", name, strings.Join(Presets(), ", "))
+	}
+
+	c := DefaultConfig()
+	preset(&c)
+	return c, nil
+}
@@ -0,0 +1,85 @@
+package gouwu
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestPresets(t *testing.T) {
+	if names := Presets(); !slices.Contains(names, "default") || !slices.IsSorted(names) {
+		t.Errorf("Presets() = %v", names)
+	}
+
+	for _, name := range Presets() {
+		config, err := PresetConfig(name)
+		if err != nil {
+			t.Errorf("PresetConfig(%q) error = %v", name, err)
+			continue
+		}
+		if err := config.Validate(); err != nil {
+			t.Errorf("preset %q is invalid: %v", name, err)
+		}
+
+		uwuifier := New(WithPreset(name))
+		if !reflect.DeepEqual(uwuifier.Config(), config) {
+			t.Errorf("WithPreset(%q) config = %+v, want %+v", name, uwuifier.Config(), config)
+		}
+	}
+
+	if config, _ := PresetConfig("default"); !reflect.DeepEqual(config, DefaultConfig()) {
+		t.Error(`preset "default" differs from DefaultConfig()`)
+	}
+	if _, err := PresetConfig("nope"); err == nil {
+		t.Error("PresetConfig() should reject unknown presets")
+	}
+}
+
+func TestPresetKeepsOptions(t *testing.T) {
+	opts := []Option{WithRandomSource(NondeterministicSource()), WithFaces("UwU"), WithProtectedWords("gopher")}
+	preset, _ := PresetConfig("chaotic")
+
+	// The preset changes its modifiers whatever the order, and leaves the
+	// other options alone
+	for _, uwuifier := range []*Uwuifier{
+		New(append(opts, WithPreset("chaotic"))...),
+		New(append([]Option{WithPreset("chaotic")}, opts...)...),
+	} {
+		config := uwuifier.Config()
+		if !reflect.DeepEqual(config.Modifiers, preset.Modifiers) {
+			t.Errorf("Modifiers = %+v, want %+v", config.Modifiers, preset.Modifiers)
+		}
+		if !reflect.DeepEqual(config.Faces, []string{"UwU"}) || !reflect.DeepEqual(config.ProtectedWords, []string{"gopher"}) {
+			t.Errorf("WithPreset() reset faces %v and protected words %v", config.Faces, config.ProtectedWords)
+		}
+		if _, ok := uwuifier.source.(nondeterministicSource); !ok {
+			t.Errorf("WithPreset() reset the random source to %v", uwuifier.source)
+		}
+	}
+}
+
+func TestPresetKeepsModifiers(t *testing.T) {
+	nya := NyaModifier{Endings: 1}
+	elongation := ElongationModifier{Vowels: 0.1, Tildes: 0.2}
+
+	uwuifier := New(WithNya(nya), WithElongation(elongation), WithPreset("subtle"))
+	if modifier := uwuifier.NyaModifier(); modifier != nya {
+		t.Errorf("NyaModifier() = %+v, want %+v", modifier, nya)
+	}
+	if modifier := uwuifier.ElongationModifier(); modifier != elongation {
+		t.Errorf("ElongationModifier() = %+v, want %+v", modifier, elongation)
+	}
+	if words := uwuifier.WordsModifier(); words != 0.5 {
+		t.Errorf("WordsModifier() = %v, want 0.5", words)
+	}
+
+	// Chaotic sets its own elongation but keeps the catgirl stage
+	preset, _ := PresetConfig("chaotic")
+	uwuifier = New(WithNya(nya), WithElongation(elongation), WithPreset("chaotic"))
+	if modifier := uwuifier.NyaModifier(); modifier != nya {
+		t.Errorf("NyaModifier() = %+v, want %+v", modifier, nya)
+	}
+	if modifier := uwuifier.ElongationModifier(); modifier != preset.Modifiers.Elongation {
+		t.Errorf("ElongationModifier() = %+v, want %+v", modifier, preset.Modifiers.Elongation)
+	}
+}
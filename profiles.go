@@ -0,0 +1,280 @@
+package gouwu
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Profile is a named variant of a configuration that changes only some of
+// its fields. Unset fields are inherited from the profile it extends, or
+// from the top level of the configuration if it extends none. Lists are
+// replaced when set, extended by the Extra lists and trimmed by the Without
+// lists, where an empty Without list removes everything like the Without
+// options do. Weights, ratings, moods, lexicon, face descriptions and
+// dictionary entries are merged.
+type Profile struct {
+	Extends string `json:"extends,omitempty"`
+
+	Version   *AlgorithmVersion  `json:"version,omitempty"`
+	Modifiers *ModifierOverrides `json:"modifiers,omitempty"`
+
+	StutterCounts []float64 `json:"stutter_counts,omitempty"`
+
+	Faces        []string `json:"faces,omitempty"`
+	Actions      []string `json:"actions,omitempty"`
+	Exclamations []string `json:"exclamations,omitempty"`
+
+	ExtraFaces        []string `json:"extra_faces,omitempty"`
+	ExtraActions      []string `json:"extra_actions,omitempty"`
+	ExtraExclamations []string `json:"extra_exclamations,omitempty"`
+
+	WithoutFaces        []string `json:"without_faces,omitempty"`
+	WithoutActions      []string `json:"without_actions,omitempty"`
+	WithoutExclamations []string `json:"without_exclamations,omitempty"`
+
+	FaceWeights        Weights `json:"face_weights,omitempty"`
+	ActionWeights      Weights `json:"action_weights,omitempty"`
+	ExclamationWeights Weights `json:"exclamation_weights,omitempty"`
+
+	Rating  *Rating `json:"rating,omitempty"`
+	Ratings Ratings `json:"ratings,omitempty"`
+
+	ASCIIOnly *bool `json:"ascii_only,omitempty"`
+
+	Moods     Moods   `json:"moods,omitempty"`
+	Sentiment Lexicon `json:"sentiment,omitempty"`
+
+	Triggers     []Trigger `json:"triggers,omitempty"`
+	TriggerLimit *int      `json:"trigger_limit,omitempty"`
+
+	Accessibility    *Accessibility    `json:"accessibility,omitempty"`
+	FaceMarker       *FaceMarker       `json:"face_marker,omitempty"`
+	FaceDescriptions map[string]string `json:"face_descriptions,omitempty"`
+
+	Capitalization *Capitalization `json:"capitalization,omitempty"`
+
+	Owoify         *OwoifyLevel        `json:"owoify,omitempty"`
+	Phonetics      *PhoneticsOverrides `json:"phonetics,omitempty"`
+	Rules          []Rule              `json:"rules,omitempty"`
+	Dictionary     map[string]string   `json:"dictionary,omitempty"`
+	ProtectedWords []string            `json:"protected_words,omitempty"`
+
+	Cache          *int  `json:"cache,omitempty"`
+	UpstreamParity *bool `json:"upstream_parity,omitempty"`
+}
+
+// ModifierOverrides changes some of the modifiers of a configuration
+type ModifierOverrides struct {
+	Words        *float64         `json:"words,omitempty"`
+	Spaces       *SpacesOverrides `json:"spaces,omitempty"`
+	Exclamations *float64         `json:"exclamations,omitempty"`
+
+	Elongation *ElongationOverrides `json:"elongation,omitempty"`
+	Nya        *NyaOverrides        `json:"nya,omitempty"`
+}
+
+// SpacesOverrides changes some of the spaces probabilities of a configuration
+type SpacesOverrides struct {
+	Faces    *float64 `json:"faces,omitempty"`
+	Actions  *float64 `json:"actions,omitempty"`
+	Stutters *float64 `json:"stutters,omitempty"`
+}
+
+// ElongationOverrides changes some of the elongation probabilities of a
+// configuration
+type ElongationOverrides struct {
+	Vowels *float64 `json:"vowels,omitempty"`
+	Tildes *float64 `json:"tildes,omitempty"`
+}
+
+// NyaOverrides changes some of the catgirl stage probabilities of a
+// configuration
+type NyaOverrides struct {
+	Endings  *float64 `json:"endings,omitempty"`
+	Emphasis *float64 `json:"emphasis,omitempty"`
+	Purrs    *float64 `json:"purrs,omitempty"`
+	Mews     *float64 `json:"mews,omitempty"`
+}
+
+// PhoneticsOverrides turns some of the rules of the phonetic pack of a
+// configuration on or off
+type PhoneticsOverrides struct {
+	InitialTh   *bool `json:"initial_th,omitempty"`
+	MedialTh    *bool `json:"medial_th,omitempty"`
+	Ing         *bool `json:"ing,omitempty"`
+	Diminutives *bool `json:"diminutives,omitempty"`
+	You         *bool `json:"you,omitempty"`
+}
+
+// NewFromProfile creates an Uwuifier from the named profile of a
+// configuration, applying opts after it
+func NewFromProfile(c Config, name string, opts ...Option) (*Uwuifier, error) {
+	resolved, err := c.ResolveProfile(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewFromConfig(resolved, opts...)
+}
+
+// ResolveProfile returns the concrete configuration of the named profile,
+// without any profiles of its own
+func (c Config) ResolveProfile(name string) (Config, error) {
+	chain, err := c.profileChain(name)
+	if err != nil {
+		return Config{}, err
+	}
+
+	resolved := c.clone()
+	resolved.Profiles = nil
+	for _, profile := range slices.Backward(chain) {
+		c.Profiles[profile].applyTo(&resolved)
+	}
+	return resolved, nil
+}
+
+// ProfileDiff lists what the named profile changes about the profile it
+// extends, or about the top level configuration if it extends none
+func (c Config) ProfileDiff(name string) ([]Change, error) {
+	resolved, err := c.ResolveProfile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := c.clone()
+	parent.Profiles = nil
+	if extends := c.Profiles[name].Extends; extends != "" {
+		if parent, err = c.ResolveProfile(extends); err != nil {
+			return nil, err
+		}
+	}
+	return DiffConfig(parent, resolved), nil
+}
+
+// profileChain returns the named profile followed by every profile it
+// extends, nearest first
+func (c Config) profileChain(name string) ([]string, error) {
+	if _, ok := c.Profiles[name]; !ok {
+		return nil, fmt.Errorf("unknown profile %q", name)
+	}
+
+	var chain []string
+	for next := name; next != ""; next = c.Profiles[next].Extends {
+		if slices.Contains(chain, next) {
+			return nil, fmt.Errorf("profile %q extends itself: %s -> %s", name, strings.Join(chain, " -> "), next)
+		}
+		if _, ok := c.Profiles[next]; !ok {
+			return nil, fmt.Errorf("profile %q extends unknown profile %q", chain[len(chain)-1], next)
+		}
+		chain = append(chain, next)
+	}
+	return chain, nil
+}
+
+// applyTo changes c as the profile describes
+func (p Profile) applyTo(c *Config) {
+	override(&c.Version, p.Version)
+	if m := p.Modifiers; m != nil {
+		override(&c.Modifiers.Words, m.Words)
+		override(&c.Modifiers.Exclamations, m.Exclamations)
+		if s := m.Spaces; s != nil {
+			override(&c.Modifiers.Spaces.Faces, s.Faces)
+			override(&c.Modifiers.Spaces.Actions, s.Actions)
+			override(&c.Modifiers.Spaces.Stutters, s.Stutters)
+		}
+		if e := m.Elongation; e != nil {
+			override(&c.Modifiers.Elongation.Vowels, e.Vowels)
+			override(&c.Modifiers.Elongation.Tildes, e.Tildes)
+		}
+		if n := m.Nya; n != nil {
+			override(&c.Modifiers.Nya.Endings, n.Endings)
+			override(&c.Modifiers.Nya.Emphasis, n.Emphasis)
+			override(&c.Modifiers.Nya.Purrs, n.Purrs)
+			override(&c.Modifiers.Nya.Mews, n.Mews)
+		}
+	}
+
+	if p.StutterCounts != nil {
+		c.StutterCounts = slices.Clone(p.StutterCounts)
+	}
+
+	c.Faces = editList(c.Faces, p.Faces, p.ExtraFaces, p.WithoutFaces)
+	c.Actions = editList(c.Actions, p.Actions, p.ExtraActions, p.WithoutActions)
+	c.Exclamations = editList(c.Exclamations, p.Exclamations, p.ExtraExclamations, p.WithoutExclamations)
+
+	c.FaceWeights = mergeMap(c.FaceWeights, p.FaceWeights)
+	c.ActionWeights = mergeMap(c.ActionWeights, p.ActionWeights)
+	c.ExclamationWeights = mergeMap(c.ExclamationWeights, p.ExclamationWeights)
+
+	override(&c.Rating, p.Rating)
+	c.Ratings = mergeMap(c.Ratings, p.Ratings)
+	override(&c.ASCIIOnly, p.ASCIIOnly)
+	c.Moods = mergeMap(c.Moods, p.Moods)
+	c.Sentiment = mergeMap(c.Sentiment, p.Sentiment)
+
+	if p.Triggers != nil {
+		c.Triggers = slices.Clone(p.Triggers)
+	}
+	override(&c.TriggerLimit, p.TriggerLimit)
+
+	override(&c.Accessibility, p.Accessibility)
+	override(&c.FaceMarker, p.FaceMarker)
+	c.FaceDescriptions = mergeMap(c.FaceDescriptions, p.FaceDescriptions)
+
+	override(&c.Capitalization, p.Capitalization)
+	override(&c.Owoify, p.Owoify)
+	if ph := p.Phonetics; ph != nil {
+		override(&c.Phonetics.InitialTh, ph.InitialTh)
+		override(&c.Phonetics.MedialTh, ph.MedialTh)
+		override(&c.Phonetics.Ing, ph.Ing)
+		override(&c.Phonetics.Diminutives, ph.Diminutives)
+		override(&c.Phonetics.You, ph.You)
+	}
+	if p.Rules != nil {
+		c.Rules = slices.Clone(p.Rules)
+	}
+	c.Dictionary = mergeMap(c.Dictionary, p.Dictionary)
+	if p.ProtectedWords != nil {
+		c.ProtectedWords = slices.Clone(p.ProtectedWords)
+	}
+
+	override(&c.Cache, p.Cache)
+	override(&c.UpstreamParity, p.UpstreamParity)
+}
+
+// override sets dst to value if it is set
+func override[T any](dst *T, value *T) {
+	if value != nil {
+		*dst = *value
+	}
+}
+
+// editList replaces list if replacement is set, then adds extra and
+// removes without
+func editList(list, replacement, extra, without []string) []string {
+	if replacement != nil {
+		list = slices.Clone(replacement)
+	}
+	if extra != nil {
+		list = appendExpressions(list, extra)
+	}
+	if without != nil {
+		list = removeExpressions(list, without)
+	}
+	return list
+}
+
+// mergeMap returns a copy of m with the entries of overrides added
+func mergeMap[M ~map[string]V, V any](m, overrides M) M {
+	if len(overrides) == 0 {
+		return m
+	}
+
+	merged := maps.Clone(m)
+	if merged == nil {
+		merged = make(M, len(overrides))
+	}
+	maps.Copy(merged, overrides)
+	return merged
+}
@@ -0,0 +1,219 @@
+package gouwu
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func loadProfiles(t *testing.T) Config {
+	t.Helper()
+
+	config, err := LoadConfig("testdata/profiles.json")
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	return config
+}
+
+func TestResolveProfile(t *testing.T) {
+	config := loadProfiles(t)
+
+	shy, err := config.ResolveProfile("shy")
+	if err != nil {
+		t.Fatalf("ResolveProfile(shy) error = %v", err)
+	}
+	if shy.Modifiers.Words != 0.8 || shy.Modifiers.Spaces != (SpacesModifier{Faces: 0.05, Actions: 0, Stutters: 0.3}) {
+		t.Errorf("shy modifiers = %+v", shy.Modifiers)
+	}
+	if !slices.Contains(shy.Faces, "(⁄ ⁄>⁄ ▽ ⁄<⁄ ⁄)") || len(shy.Faces) != len(config.Faces)+1 {
+		t.Errorf("shy faces = %v", shy.Faces)
+	}
+	if slices.Contains(shy.Actions, "*twerks*") || len(shy.Actions) != len(config.Actions)-3 {
+		t.Errorf("shy actions = %v", shy.Actions)
+	}
+	if shy.Profiles != nil {
+		t.Error("resolved profiles should not have profiles of their own")
+	}
+
+	catgirl, err := config.ResolveProfile("catgirl")
+	if err != nil {
+		t.Fatalf("ResolveProfile(catgirl) error = %v", err)
+	}
+	if catgirl.Modifiers.Spaces.Stutters != 0.3 || !reflect.DeepEqual(catgirl.Actions, shy.Actions) {
+		t.Error("catgirl should inherit from shy")
+	}
+	if !reflect.DeepEqual(catgirl.Faces, []string{":3", "=^w^="}) || catgirl.Dictionary["now"] != "nyow" {
+		t.Errorf("catgirl overrides = %v %v", catgirl.Faces, catgirl.Dictionary)
+	}
+
+	// Resolving leaves the configuration untouched
+	if !reflect.DeepEqual(config, loadProfiles(t)) {
+		t.Error("ResolveProfile() modified the configuration")
+	}
+}
+
+func TestNewFromProfile(t *testing.T) {
+	config := loadProfiles(t)
+
+	uwuifier, err := NewFromProfile(config, "hyper")
+	if err != nil {
+		t.Fatalf("NewFromProfile() error = %v", err)
+	}
+	if uwuifier.WordsModifier() != 1 || !slices.Equal(uwuifier.Exclamations, []string{"!!!!", "?!?!?!"}) {
+		t.Errorf("hyper = %v %v", uwuifier.WordsModifier(), uwuifier.Exclamations)
+	}
+	if uwuifier.ActionWeights()["*runs away*"] != 3 {
+		t.Errorf("hyper action weights = %v", uwuifier.ActionWeights())
+	}
+
+	if _, err := NewFromProfile(config, "sleepy"); err == nil {
+		t.Error("NewFromProfile() should reject unknown profiles")
+	}
+}
+
+func TestProfileEdits(t *testing.T) {
+	config := Config{
+		Faces:   []string{"UwU", "OwO"},
+		Actions: []string{"*blushes*"},
+		Profiles: map[string]Profile{
+			"quiet": {WithoutActions: []string{}, ExtraFaces: []string{"OwO", "^w^"}},
+		},
+	}
+
+	quiet, err := config.ResolveProfile("quiet")
+	if err != nil {
+		t.Fatalf("ResolveProfile() error = %v", err)
+	}
+	if !slices.Equal(quiet.Faces, []string{"UwU", "OwO", "^w^"}) || len(quiet.Actions) != 0 {
+		t.Errorf("quiet = %v %v", quiet.Faces, quiet.Actions)
+	}
+}
+
+func TestProfileErrors(t *testing.T) {
+	words := 2.0
+	config := DefaultConfig()
+	config.Profiles = map[string]Profile{
+		"a":     {Extends: "b"},
+		"b":     {Extends: "c"},
+		"c":     {Extends: "a"},
+		"self":  {Extends: "self"},
+		"lost":  {Extends: "missing"},
+		"loud":  {Modifiers: &ModifierOverrides{Words: &words}},
+		"fine":  {},
+		"child": {Extends: "fine"},
+	}
+
+	testCases := []struct {
+		name string
+		want string
+	}{
+		{"a", "a -> b -> c -> a"},
+		{"self", "self -> self"},
+		{"lost", `extends unknown profile "missing"`},
+		{"nope", `unknown profile "nope"`},
+	}
+
+	for _, tc := range testCases {
+		_, err := config.ResolveProfile(tc.name)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("ResolveProfile(%q) error = %v, want it to mention %q", tc.name, err, tc.want)
+		}
+	}
+
+	err := config.Validate()
+	if err == nil {
+		t.Fatal("Validate() should have errored")
+	}
+	for _, want := range []string{"profiles.a", "profiles.self", "profiles.lost", "profiles.loud.modifiers.words"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() error = %v, want it to mention %s", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "profiles.child") {
+		t.Errorf("Validate() error = %v, child of a valid profile is valid", err)
+	}
+}
+
+func TestProfileUnknownField(t *testing.T) {
+	_, err := ParseConfig([]byte(`{"profiles": {"shy": {"extend": "hyper"}}}`))
+	if err == nil || !strings.Contains(err.Error(), `unknown field "extend"`) {
+		t.Errorf("ParseConfig() error = %v, want an unknown field error", err)
+	}
+}
+
+func TestProfileDiff(t *testing.T) {
+	config := loadProfiles(t)
+
+	testCases := []struct {
+		name     string
+		expected []string
+	}{
+		{"shy", []string{
+			"modifiers.spaces.actions: 0.05 -> 0",
+			"modifiers.spaces.stutters: 0.1 -> 0.3",
+			`faces: + "(⁄ ⁄>⁄ ▽ ⁄<⁄ ⁄)"`,
+			`actions: - "*screams*"`,
+			`actions: - "*twerks*"`,
+			`actions: - "*starts twerking*"`,
+		}},
+		{"catgirl", []string{
+			`faces: - "(・` + "`ω´" + `・)"`,
+			`faces: - ";;w;;"`,
+			`faces: - "OwO"`,
+			`faces: - "UwU"`,
+			`faces: - ">w<"`,
+			`faces: - "^w^"`,
+			`faces: - "ÚwÚ"`,
+			`faces: - "^-^"`,
+			`faces: - "x3"`,
+			`faces: - "(⁄ ⁄>⁄ ▽ ⁄<⁄ ⁄)"`,
+			`faces: + "=^w^="`,
+			`dictionary["now"]: + "nyow"`,
+		}},
+		{"hyper", []string{
+			"modifiers.words: 0.8 -> 1",
+			"modifiers.spaces.faces: 0.05 -> 0.2",
+			"modifiers.spaces.actions: 0.05 -> 0.2",
+			`exclamations: - "!?"`,
+			`exclamations: - "?!!"`,
+			`exclamations: - "?!?1"`,
+			`exclamations: - "!!11"`,
+			`exclamations: - "?!?!"`,
+			`exclamations: + "!!!!"`,
+			`exclamations: + "?!?!?!"`,
+			`action_weights["*runs away*"]: + 3`,
+		}},
+	}
+
+	for _, tc := range testCases {
+		changes, err := config.ProfileDiff(tc.name)
+		if err != nil {
+			t.Fatalf("ProfileDiff(%q) error = %v", tc.name, err)
+		}
+
+		result := make([]string, len(changes))
+		for i, change := range changes {
+			result[i] = change.String()
+		}
+		if !slices.Equal(result, tc.expected) {
+			t.Errorf("ProfileDiff(%q) =\n%s\nwant\n%s", tc.name, strings.Join(result, "\n"), strings.Join(tc.expected, "\n"))
+		}
+	}
+}
+
+func TestDiffConfigReorder(t *testing.T) {
+	from := Config{Faces: []string{"UwU", "OwO"}, Cache: 16}
+	to := Config{Faces: []string{"OwO", "UwU"}, Cache: 16}
+
+	changes := DiffConfig(from, to)
+	expected := []Change{{Field: "faces", From: `["UwU", "OwO"]`, To: `["OwO", "UwU"]`}}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("DiffConfig() = %v, want %v", changes, expected)
+	}
+
+	if changes := DiffConfig(from, from); len(changes) != 0 {
+		t.Errorf("DiffConfig() of equal configs = %v", changes)
+	}
+}
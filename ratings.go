@@ -0,0 +1,195 @@
+package gouwu
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+)
+
+// Rating is how suitable a face, action or exclamation is for a general
+// audience. Higher ratings are less suitable.
+type Rating int
+
+const (
+	// RatingSafe expressions are fine anywhere
+	RatingSafe Rating = iota + 1
+
+	// RatingSuggestive expressions are innuendo, like "*sees bulge*"
+	RatingSuggestive
+)
+
+// Ratings maps faces, actions or exclamations to their rating
+type Ratings map[string]Rating
+
+// builtinRatings rates the built-in expressions that are not safe.
+// Expressions rated neither here nor by the uwuifier are safe.
+var builtinRatings = Ratings{
+	"*sees bulge*":      RatingSuggestive,
+	"*notices buldge*":  RatingSuggestive,
+	"*twerks*":          RatingSuggestive,
+	"*starts twerking*": RatingSuggestive,
+}
+
+// String returns the name of the rating, or "" for no rating
+func (r Rating) String() string {
+	switch r {
+	case 0:
+		return ""
+	case RatingSafe:
+		return "safe"
+	case RatingSuggestive:
+		return "suggestive"
+	}
+	return fmt.Sprintf("Rating(%d)", int(r))
+}
+
+// MarshalText encodes the rating as its name
+func (r Rating) MarshalText() ([]byte, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText decodes a rating from its name
+func (r *Rating) UnmarshalText(text []byte) error {
+	for _, rating := range []Rating{0, RatingSafe, RatingSuggestive} {
+		if rating.String() == string(text) {
+			*r = rating
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown rating %q, want safe or suggestive", text)
+}
+
+// validate checks that r is a known rating or no rating
+func (r Rating) validate() error {
+	if r < 0 || r > RatingSuggestive {
+		return errors.New("unknown rating")
+	}
+	return nil
+}
+
+// WithRating only lets faces, actions and exclamations rated up to max be
+// picked, see SetRating
+func WithRating(max Rating) Option {
+	return func(u *Uwuifier) {
+		u.SetRating(max)
+	}
+}
+
+// WithRatings rates faces, actions and exclamations, see SetRatings
+func WithRatings(ratings Ratings) Option {
+	return func(u *Uwuifier) {
+		u.SetRatings(ratings)
+	}
+}
+
+// Rating returns the highest rating that may be picked, or 0 if any may
+func (u *Uwuifier) Rating() Rating { return u.rating }
+
+// Ratings returns a copy of the ratings set with SetRatings
+func (u *Uwuifier) Ratings() Ratings { return maps.Clone(u.ratings) }
+
+// RatingOf returns the rating of a face, action or exclamation
+func (u *Uwuifier) RatingOf(expression string) Rating {
+	return u.filter().ratingOf(expression)
+}
+
+// SetRating only lets faces, actions and exclamations rated up to max be
+// picked, as if the others were not in the lists. A max of 0 lets any
+// expression be picked, which is the default.
+func (u *Uwuifier) SetRating(max Rating) error {
+	if err := max.validate(); err != nil {
+		return err
+	}
+	u.rating = max
+	u.changed()
+	return nil
+}
+
+// SetRatings rates faces, actions and exclamations, overriding the ratings
+// of the built-in ones. Unrated expressions are safe, except for the
+// built-in suggestive actions.
+func (u *Uwuifier) SetRatings(ratings Ratings) error {
+	for expression, rating := range ratings {
+		if rating == 0 || rating.validate() != nil {
+			return fmt.Errorf("unknown rating %d for %q", int(rating), expression)
+		}
+	}
+	u.ratings = maps.Clone(ratings)
+	u.changed()
+	return nil
+}
+
+// filter decides which expressions may be picked
+type filter struct {
+	maxRating Rating
+	ratings   Ratings
+	ascii     bool
+
+	// The mood preference, see preferring
+	moods Moods
+	mood  Mood
+	tier  moodTier
+}
+
+// filter returns the expression filter of the uwuifier
+func (u *Uwuifier) filter() filter {
+	return filter{maxRating: u.rating, ratings: u.ratings, ascii: u.asciiOnly, moods: u.moods}
+}
+
+// active reports whether the filter may reject anything
+func (f filter) active() bool {
+	return f.maxRating != 0 || f.ascii || f.tier != tierAny
+}
+
+// allows reports whether expression may be picked
+func (f filter) allows(expression string) bool {
+	return (f.maxRating == 0 || f.ratingOf(expression) <= f.maxRating) &&
+		(!f.ascii || isASCII(expression)) && f.suits(expression)
+}
+
+// ratingOf returns the rating of expression
+func (f filter) ratingOf(expression string) Rating {
+	if rating, ok := f.ratings[expression]; ok {
+		return rating
+	}
+	if rating, ok := builtinRatings[expression]; ok {
+		return rating
+	}
+	return RatingSafe
+}
+
+// count returns the number of expressions in list that may be picked
+func (f filter) count(list []string) int {
+	if !f.active() {
+		return len(list)
+	}
+
+	n := 0
+	for _, expression := range list {
+		if f.allows(expression) {
+			n++
+		}
+	}
+	return n
+}
+
+// nth returns the nth expression in list that may be picked
+func (f filter) nth(list []string, n int) string {
+	if !f.active() {
+		return list[n]
+	}
+
+	for _, expression := range list {
+		if !f.allows(expression) {
+			continue
+		}
+		if n == 0 {
+			return expression
+		}
+		n--
+	}
+	panic("gouwu: filter.nth out of range")
+}
@@ -0,0 +1,176 @@
+package gouwu
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// spacedInserts collects the faces and actions UwuifySpaces adds to many words
+func spacedInserts(u *Uwuifier) map[string]int {
+	counts := map[string]int{}
+	for i := 0; i < 3000; i++ {
+		result := u.UwuifySpaces(fmt.Sprintf("word%d", i))
+		if i := strings.IndexByte(result, ' '); i >= 0 {
+			counts[result[i+1:]]++
+		}
+	}
+	return counts
+}
+
+func TestWithRating(t *testing.T) {
+	suggestive := []string{"*sees bulge*", "*notices buldge*", "*twerks*", "*starts twerking*"}
+
+	all := spacedInserts(New(WithSpaces(SpacesModifier{Actions: 1})))
+	for _, action := range suggestive {
+		if all[action] == 0 {
+			t.Errorf("%q was never picked without a rating", action)
+		}
+	}
+
+	for _, version := range []AlgorithmVersion{AlgorithmV1, AlgorithmV2} {
+		safe := spacedInserts(New(
+			WithAlgorithmVersion(version),
+			WithSpaces(SpacesModifier{Actions: 1}),
+			WithRating(RatingSafe),
+		))
+		for _, action := range suggestive {
+			if safe[action] != 0 {
+				t.Errorf("v%d: %q was picked %d times with RatingSafe", version, action, safe[action])
+			}
+		}
+		if len(safe) != len(New().Actions)-len(suggestive) {
+			t.Errorf("v%d: picked %d actions, want every safe one", version, len(safe))
+		}
+	}
+}
+
+func TestWithRatingWeighted(t *testing.T) {
+	uwuifier := New(
+		WithSpaces(SpacesModifier{Actions: 1}),
+		WithActions("*twerks*", "*blushes*", "*cries*"),
+		WithActionWeights(Weights{"*twerks*": 100}),
+		WithRating(RatingSafe),
+	)
+
+	counts := spacedInserts(uwuifier)
+	if counts["*twerks*"] != 0 || counts["*blushes*"] == 0 || counts["*cries*"] == 0 {
+		t.Errorf("weighted picks with RatingSafe = %v", counts)
+	}
+}
+
+func TestWithRatings(t *testing.T) {
+	uwuifier := New(
+		WithSpaces(SpacesModifier{Faces: 1}),
+		WithFaces("UwU", "( ͡° ͜ʖ ͡°)"),
+		WithRatings(Ratings{"( ͡° ͜ʖ ͡°)": RatingSuggestive, "*twerks*": RatingSafe}),
+		WithRating(RatingSafe),
+	)
+
+	if counts := spacedInserts(uwuifier); len(counts) != 1 || counts["UwU"] == 0 {
+		t.Errorf("faces picked = %v, want only UwU", counts)
+	}
+
+	testCases := []struct {
+		expression string
+		expected   Rating
+	}{
+		{"UwU", RatingSafe},
+		{"( ͡° ͜ʖ ͡°)", RatingSuggestive},
+		{"*twerks*", RatingSafe},
+		{"*sees bulge*", RatingSuggestive},
+	}
+	for _, tc := range testCases {
+		if rating := uwuifier.RatingOf(tc.expression); rating != tc.expected {
+			t.Errorf("RatingOf(%q) = %v, want %v", tc.expression, rating, tc.expected)
+		}
+	}
+}
+
+func TestRatingFiltersEverything(t *testing.T) {
+	uwuifier := New(
+		WithWords(0),
+		WithSpaces(SpacesModifier{Actions: 1}),
+		WithActions("*twerks*"),
+		WithExclamationList("?!"),
+		WithRatings(Ratings{"?!": RatingSuggestive}),
+		WithRating(RatingSafe),
+	)
+
+	// With nothing to pick every word falls through to stutters
+	input := "Hello world!"
+	if result := uwuifier.UwuifySentence(input); strings.Contains(result, "*") || !strings.HasSuffix(result, "world!") {
+		t.Errorf("UwuifySentence(%q) = %q, want no actions or exclamations", input, result)
+	}
+}
+
+func TestRatingUpstreamParity(t *testing.T) {
+	uwuifier := New(WithUpstreamParity(), WithSpaces(SpacesModifier{Actions: 1}), WithRating(RatingSafe))
+	trimmed := New(WithUpstreamParity(), WithSpaces(SpacesModifier{Actions: 1}),
+		WithoutActions("*sees bulge*", "*notices buldge*", "*twerks*", "*starts twerking*"))
+
+	input := "Hello world, this is a test sentence with many words in it"
+	if result, expected := uwuifier.UwuifySpaces(input), trimmed.UwuifySpaces(input); result != expected {
+		t.Errorf("UwuifySpaces() = %q, want %q", result, expected)
+	}
+}
+
+func TestRatingValidation(t *testing.T) {
+	uwuifier := New()
+	for _, rating := range []Rating{-1, RatingSuggestive + 1} {
+		if err := uwuifier.SetRating(rating); err == nil {
+			t.Errorf("SetRating(%d) should have errored", rating)
+		}
+		if err := uwuifier.SetRatings(Ratings{"UwU": rating}); err == nil {
+			t.Errorf("SetRatings(%d) should have errored", rating)
+		}
+	}
+	if err := uwuifier.SetRatings(Ratings{"UwU": 0}); err == nil {
+		t.Error("SetRatings() should reject missing ratings")
+	}
+}
+
+func TestRatingJSON(t *testing.T) {
+	data, err := json.Marshal(Ratings{"*twerks*": RatingSuggestive, "UwU": RatingSafe})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(data) != `{"*twerks*":"suggestive","UwU":"safe"}` {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	var ratings Ratings
+	if err := json.Unmarshal(data, &ratings); err != nil || ratings["*twerks*"] != RatingSuggestive {
+		t.Errorf("json.Unmarshal() = %v, %v", ratings, err)
+	}
+	if err := json.Unmarshal([]byte(`{"UwU": "spicy"}`), &ratings); err == nil {
+		t.Error("json.Unmarshal() should reject unknown ratings")
+	}
+}
+
+func TestSFWPreset(t *testing.T) {
+	config, err := PresetConfig("sfw")
+	if err != nil {
+		t.Fatalf("PresetConfig(sfw) error = %v", err)
+	}
+	if config.Rating != RatingSafe {
+		t.Errorf("sfw rating = %v, want safe", config.Rating)
+	}
+
+	counts := spacedInserts(New(WithPreset("sfw"), WithSpaces(SpacesModifier{Actions: 1})))
+	for action := range counts {
+		if slices.Contains([]string{"*sees bulge*", "*notices buldge*", "*twerks*", "*starts twerking*"}, action) {
+			t.Errorf("sfw preset picked %q", action)
+		}
+	}
+
+	env, err := configFromEnv(envLookup(map[string]string{"GOUWU_RATING": "safe"}))
+	if err != nil || env.Rating != RatingSafe {
+		t.Errorf("GOUWU_RATING=safe gave %v, %v", env.Rating, err)
+	}
+	if _, err := configFromEnv(envLookup(map[string]string{"GOUWU_RATING": "spicy"})); err == nil {
+		t.Error("GOUWU_RATING=spicy should have errored")
+	}
+}
@@ -0,0 +1,163 @@
+package gouwu
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// replaceFunc appends src with a rule applied to dst
+type replaceFunc func(dst, src []byte) []byte
+
+// apply appends word with the replacement applied to dst. Rules built by this
+// package carry a hand-written matcher; rules built by callers fall back to
+// their regular expression.
+func (r UwuReplacement) apply(dst, word []byte) []byte {
+	if r.replace != nil {
+		return r.replace(dst, word)
+	}
+	if !r.Pattern.Match(word) {
+		return append(dst, word...)
+	}
+	return append(dst, r.Pattern.ReplaceAll(word, []byte(r.Replacement))...)
+}
+
+// locateFunc returns the bounds of every match of a rule in src
+type locateFunc func(src []byte) [][]int
+
+// applyChoices appends word with every match of the rule replaced by one of
+// its choices to dst, picking a choice for each match. In ASCII-only mode a
+// match whose pick is not ASCII is left as it is.
+func (r UwuReplacement) applyChoices(dst, word []byte, rand *wordRand, asciiOnly bool) []byte {
+	var matches [][]int
+	if r.locate != nil {
+		matches = r.locate(word)
+	} else {
+		matches = r.Pattern.FindAllIndex(word, -1)
+	}
+
+	last := 0
+	for _, match := range matches {
+		choice := r.choices[rand.intn(len(r.choices))]
+		if asciiOnly && !isASCII(choice) {
+			continue
+		}
+		dst = append(dst, word[last:match[0]]...)
+		dst = append(dst, choice...)
+		last = match[1]
+	}
+	return append(dst, word[last:]...)
+}
+
+// literalRule replaces every occurrence of old with new
+func literalRule(old, new string) UwuReplacement {
+	oldBytes := []byte(old)
+	return UwuReplacement{
+		Pattern:     regexp.MustCompile(regexp.QuoteMeta(old)),
+		Replacement: new,
+		replace: func(dst, src []byte) []byte {
+			for {
+				i := bytes.Index(src, oldBytes)
+				if i < 0 {
+					return append(dst, src...)
+				}
+				dst = append(dst, src[:i]...)
+				dst = append(dst, new...)
+				src = src[i+len(old):]
+			}
+		},
+	}
+}
+
+// classRule replaces every byte found in set with repl
+func classRule(set string, repl byte) UwuReplacement {
+	return UwuReplacement{
+		Pattern:     regexp.MustCompile("[" + set + "]"),
+		Replacement: string(repl),
+		replace: func(dst, src []byte) []byte {
+			for _, c := range src {
+				if strings.IndexByte(set, c) >= 0 {
+					c = repl
+				}
+				dst = append(dst, c)
+			}
+			return dst
+		},
+	}
+}
+
+// pairRule replaces lead followed by any byte in follow with repl followed
+// by that same byte, like the pattern "n([aeiou])" with "ny$1"
+func pairRule(lead byte, follow, repl string) UwuReplacement {
+	return UwuReplacement{
+		Pattern:     regexp.MustCompile(string(lead) + "([" + follow + "])"),
+		Replacement: repl + "$1",
+		replace: func(dst, src []byte) []byte {
+			for i := 0; i < len(src); i++ {
+				if src[i] == lead && i+1 < len(src) && strings.IndexByte(follow, src[i+1]) >= 0 {
+					dst = append(dst, repl...)
+					dst = append(dst, src[i+1])
+					i++
+					continue
+				}
+				dst = append(dst, src[i])
+			}
+			return dst
+		},
+	}
+}
+
+// Rule is a custom replacement rule, a regular expression and the text its
+// matches are replaced with, which may refer to submatches like "$1"
+type Rule struct {
+	Pattern     string `json:"pattern"`
+	Replacement string `json:"replacement"`
+}
+
+// WithRules sets custom replacement rules, applied to every word after the
+// built-in ones. Rules that do not compile are ignored; use SetRules to
+// check them.
+func WithRules(rules ...Rule) Option {
+	return func(u *Uwuifier) {
+		u.SetRules(rules...)
+	}
+}
+
+// Rules returns the custom replacement rules
+func (u *Uwuifier) Rules() []Rule {
+	rules := make([]Rule, len(u.rules))
+	for i, rule := range u.rules {
+		rules[i] = Rule{Pattern: rule.Pattern.String(), Replacement: rule.Replacement}
+	}
+	return rules
+}
+
+// SetRules replaces the custom replacement rules. Like the built-in rules,
+// each one is applied to a word with the words modifier as probability.
+func (u *Uwuifier) SetRules(rules ...Rule) error {
+	compiled, err := compileRules(rules)
+	if err != nil {
+		return err
+	}
+	u.rules = compiled
+	u.changed()
+	return nil
+}
+
+// compileRules compiles custom rules into replacements
+func compileRules(rules []Rule) ([]UwuReplacement, error) {
+	compiled := make([]UwuReplacement, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Pattern == "" {
+			return nil, errors.New("rule pattern must not be empty")
+		}
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule pattern %q: %w", rule.Pattern, err)
+		}
+		compiled = append(compiled, UwuReplacement{Pattern: pattern, Replacement: rule.Replacement})
+	}
+	return compiled, nil
+}
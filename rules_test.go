@@ -0,0 +1,59 @@
+package gouwu
+
+import "testing"
+
+func TestRulesMatchPatterns(t *testing.T) {
+	words := []string{
+		"", "love", "lover", "ovove", "oove", "remove", "RELOAD", "Netherlands",
+		"nnao", "NANA", "NaNo", "nINE", "café", "naïve", "Ñoño", "rLrL", "ove!",
+	}
+
+	for _, rule := range New().uwuMap {
+		for _, word := range words {
+			expected := rule.Pattern.ReplaceAllString(word, rule.Replacement)
+			result := string(rule.apply(nil, []byte(word)))
+
+			if result != expected {
+				t.Errorf("rule %q on %q = %q, want %q", rule.Pattern, word, result, expected)
+			}
+		}
+	}
+}
+
+func TestRuleRegexFallback(t *testing.T) {
+	rule := UwuReplacement{
+		Pattern:     New().uwuMap[3].Pattern,
+		Replacement: "ny$1",
+	}
+
+	result := string(rule.apply([]byte("x"), []byte("nonsense")))
+	if result != "xnyonsense" {
+		t.Errorf("apply() = %q, want %q", result, "xnyonsense")
+	}
+}
+
+func TestSetRules(t *testing.T) {
+	uwuifier := New(WithWords(1))
+	if err := uwuifier.SetRules(Rule{Pattern: "th", Replacement: "d"}, Rule{Pattern: "(o)u", Replacement: "${1}w"}); err != nil {
+		t.Fatalf("SetRules() error = %v", err)
+	}
+
+	// Custom rules run after the built-in ones
+	if result := uwuifier.UwuifyWords("the house"); result != "de howse" {
+		t.Errorf("UwuifyWords() = %q, want %q", result, "de howse")
+	}
+
+	rules := uwuifier.Rules()
+	if len(rules) != 2 || rules[1].Pattern != "(o)u" || rules[1].Replacement != "${1}w" {
+		t.Errorf("Rules() = %v", rules)
+	}
+
+	for _, rule := range []Rule{{Pattern: ""}, {Pattern: "(unclosed"}} {
+		if err := uwuifier.SetRules(rule); err == nil {
+			t.Errorf("SetRules(%+v) should have errored", rule)
+		}
+	}
+	if len(uwuifier.Rules()) != 2 {
+		t.Error("a failed SetRules should keep the previous rules")
+	}
+}
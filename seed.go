@@ -1,8 +1,12 @@
 package gouwu
 
 import (
+	"encoding"
+	"encoding/binary"
 	"errors"
 	"math"
+	"math/bits"
+	"math/rand/v2"
 )
 
 // Seed provides deterministic random number generation based on a string seed
@@ -10,10 +14,43 @@ type Seed struct {
 	a, b, c, d uint32
 }
 
+// Version and length of the encoding used by MarshalBinary
+const (
+	seedBinaryVersion = 1
+	seedBinaryLen     = 17
+)
+
+var (
+	_ rand.Source                = (*Seed)(nil)
+	_ encoding.BinaryMarshaler   = (*Seed)(nil)
+	_ encoding.BinaryUnmarshaler = (*Seed)(nil)
+)
+
 // NewSeed creates a new seeded random number generator
 func NewSeed(seed string) *Seed {
 	s := &Seed{}
-	s.initXmur3(seed)
+	initXmur3(s, seed)
+	return s
+}
+
+// NewSeedFromBytes creates a generator seeded with raw bytes, giving the
+// same sequence as NewSeed with the equivalent string
+func NewSeedFromBytes(seed []byte) *Seed {
+	s := &Seed{}
+	initXmur3(s, seed)
+	return s
+}
+
+// NewSeedFromUint64 creates a generator seeded with a number
+func NewSeedFromUint64(seed uint64) *Seed {
+	return seedFromSplitMix(seed)
+}
+
+// seedFor returns a generator seeded with str by value, so the per-word seeds
+// used by the pipeline stay on the stack
+func seedFor[T text](str T) Seed {
+	var s Seed
+	initXmur3(&s, str)
 	return s
 }
 
@@ -26,24 +63,217 @@ func (s *Seed) Random(min, max float64) (float64, error) {
 		return 0, errors.New("minimum value cannot equal maximum value")
 	}
 
-	return s.denormalize(s.sfc32(), min, max), nil
+	return s.denormalize(s.Float64(), min, max), nil
 }
 
-// RandomInt generates a random integer between min and max (inclusive)
+// RandomInt generates a random integer between min and max (inclusive), with
+// every value in the range equally likely. Earlier releases rounded a float
+// in [min,max] instead, which gave both ends half the chance of the values
+// between them and refused min == max, so the same seed now returns other
+// values. Only the uwuifier output is pinned by an algorithm version.
 func (s *Seed) RandomInt(min, max int) (int, error) {
 	if min > max {
 		return 0, errors.New("minimum value must be below maximum value")
 	}
-	if min == max {
-		return 0, errors.New("minimum value cannot equal maximum value")
+
+	// A span of 0 means the range covers every int
+	span := uint64(max) - uint64(min) + 1
+	return min + int(s.uint64n(span)), nil
+}
+
+// Intn generates a random integer in [0,n), with every value equally likely
+func (s *Seed) Intn(n int) (int, error) {
+	if n <= 0 {
+		return 0, errors.New("n must be above zero")
 	}
 
-	randFloat, err := s.Random(float64(min), float64(max))
-	if err != nil {
-		return 0, err
+	return int(s.uint64n(uint64(n))), nil
+}
+
+// Pick returns a random item from items, with every item equally likely
+func (s *Seed) Pick(items []string) (string, error) {
+	if len(items) == 0 {
+		return "", errors.New("cannot pick from an empty list")
+	}
+
+	return items[s.uint64n(uint64(len(items)))], nil
+}
+
+// Shuffle randomizes the order of n elements using swap to exchange the
+// elements at two indexes
+func (s *Seed) Shuffle(n int, swap func(i, j int)) {
+	// Fisher-Yates, walking down from the last element
+	for i := n - 1; i > 0; i-- {
+		swap(i, int(s.uint64n(uint64(i+1))))
+	}
+}
+
+// WeightedPick returns a random index into weights, each index being picked
+// with a probability proportional to its weight
+func (s *Seed) WeightedPick(weights []float64) (int, error) {
+	var total float64
+	for _, weight := range weights {
+		if weight < 0 || math.IsInf(weight, 0) || math.IsNaN(weight) {
+			return 0, errors.New("weights must be finite and not negative")
+		}
+		total += weight
 	}
+	if total <= 0 {
+		return 0, errors.New("weights must not all be zero")
+	}
+
+	return weightedIndex(s.Float64(), len(weights), total, func(i int) float64 {
+		return weights[i]
+	}), nil
+}
+
+// weightedIndex maps a draw in [0,1) onto the cumulative weights of n items
+// whose weights add up to total
+func weightedIndex(draw float64, n int, total float64, weight func(int) float64) int {
+	target := draw * total
+
+	last := 0
+	for i := 0; i < n; i++ {
+		w := weight(i)
+		if w <= 0 {
+			continue
+		}
+		if target < w {
+			return i
+		}
+		target -= w
+		last = i
+	}
+
+	// Rounding can leave a sliver past the last item
+	return last
+}
+
+// roundedInt is the RandomInt of the original port, which rounds a draw in
+// [0,1) scaled to [min,max] and so gives both ends half the chance of the
+// values between them. Algorithm version 1 keeps using it so its output
+// never changes.
+func roundedInt(draw float64, min, max int) int {
+	if min >= max {
+		return 0
+	}
+
+	return int(math.Round(draw*float64(max-min) + float64(min)))
+}
+
+// uint64n generates a random integer in [0,n) without modulo bias using
+// Lemire's multiply and reject method, or any uint64 if n is 0
+func (s *Seed) uint64n(n uint64) uint64 {
+	if n == 0 {
+		return s.Uint64()
+	}
+
+	if n <= math.MaxUint32 {
+		// Work in 32 bits so small ranges need a single step of the generator
+		return uint64(uint32n(s.sfc32, uint32(n)))
+	}
+
+	hi, lo := bits.Mul64(s.Uint64(), n)
+	if lo < n {
+		threshold := -n % n
+		for lo < threshold {
+			hi, lo = bits.Mul64(s.Uint64(), n)
+		}
+	}
+	return hi
+}
+
+// uint32n generates a random integer in [0,n) from 32 bit draws without
+// modulo bias using Lemire's multiply and reject method
+func uint32n(next func() uint32, n uint32) uint32 {
+	product := uint64(next()) * uint64(n)
+	if low := uint32(product); low < n {
+		threshold := -n % n
+		for low < threshold {
+			product = uint64(next()) * uint64(n)
+			low = uint32(product)
+		}
+	}
+	return uint32(product >> 32)
+}
+
+// Float64 generates a float64 in [0,1) from a single step of the generator
+func (s *Seed) Float64() float64 {
+	return float64(s.sfc32()) / 4294967296.0
+}
+
+// Uint32 returns the next 32 random bits
+func (s *Seed) Uint32() uint32 {
+	return s.sfc32()
+}
+
+// Uint64 combines two steps of the generator into 64 random bits, which
+// makes Seed a math/rand/v2 Source
+func (s *Seed) Uint64() uint64 {
+	return uint64(s.sfc32())<<32 | uint64(s.sfc32())
+}
+
+// Fork derives an independent generator from the current state and label.
+// The same state and label always give the same generator, different labels
+// give unrelated ones, and s itself is left untouched.
+func (s *Seed) Fork(label string) *Seed {
+	l := seedFor(label)
+	x := splitmix64(s.state() ^ splitmix64(l.state()))
+	return seedFromSplitMix(x)
+}
+
+// MarshalBinary encodes the state of the generator so it can be restored
+// with UnmarshalBinary and continue where it left off
+func (s *Seed) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, seedBinaryLen)
+	data = append(data, seedBinaryVersion)
+	for _, word := range [...]uint32{s.a, s.b, s.c, s.d} {
+		data = binary.BigEndian.AppendUint32(data, word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary restores a state encoded by MarshalBinary
+func (s *Seed) UnmarshalBinary(data []byte) error {
+	if len(data) != seedBinaryLen {
+		return errors.New("seed state must be 17 bytes long")
+	}
+	if data[0] != seedBinaryVersion {
+		return errors.New("unsupported seed state version")
+	}
+
+	s.a = binary.BigEndian.Uint32(data[1:])
+	s.b = binary.BigEndian.Uint32(data[5:])
+	s.c = binary.BigEndian.Uint32(data[9:])
+	s.d = binary.BigEndian.Uint32(data[13:])
+	return nil
+}
+
+// state folds the four state words into a single 64 bit value
+func (s *Seed) state() uint64 {
+	return splitmix64(uint64(s.a)<<32|uint64(s.b)) ^ (uint64(s.c)<<32 | uint64(s.d))
+}
+
+// seedFromSplitMix fills the state from two steps of splitmix64
+func seedFromSplitMix(x uint64) *Seed {
+	x += 0x9e3779b97f4a7c15
+	hi := splitmix64(x)
+	x += 0x9e3779b97f4a7c15
+	lo := splitmix64(x)
+
+	return &Seed{
+		a: uint32(hi >> 32), b: uint32(hi),
+		c: uint32(lo >> 32), d: uint32(lo),
+	}
+}
 
-	return int(math.Round(randFloat)), nil
+// splitmix64 is the output function of the SplitMix64 generator, a strong
+// 64 bit mixer used here to spread seeds across the whole state
+// https://prng.di.unimi.it/splitmix64.c
+func splitmix64(x uint64) uint64 {
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
 }
 
 // denormalize maps a value from [0,1] to [min,max]
@@ -53,7 +283,7 @@ func (s *Seed) denormalize(value, min, max float64) float64 {
 
 // initXmur3 initializes the PRNG state using xmur3 hash algorithm
 // https://github.com/bryc/code/blob/master/jshash/PRNGs.md
-func (s *Seed) initXmur3(str string) {
+func initXmur3[T text](s *Seed, str T) {
 	h := uint32(1779033703) ^ uint32(len(str))
 
 	for i := 0; i < len(str); i++ {
@@ -78,7 +308,7 @@ func (s *Seed) xmur3Hash(h *uint32) uint32 {
 
 // sfc32 implements the SFC32 PRNG algorithm
 // https://github.com/bryc/code/blob/master/jshash/PRNGs.md
-func (s *Seed) sfc32() float64 {
+func (s *Seed) sfc32() uint32 {
 	t := s.a + s.b
 	s.a = s.b ^ (s.b >> 9)
 	s.b = s.c + (s.c << 3)
@@ -87,8 +317,7 @@ func (s *Seed) sfc32() float64 {
 	t = t + s.d
 	s.c = s.c + t
 
-	// Convert to float64 in range [0,1)
-	return float64(t) / 4294967296.0
+	return t
 }
 
 // imul32 performs 32-bit integer multiplication (equivalent to JS Math.imul)
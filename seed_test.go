@@ -2,6 +2,8 @@ package gouwu
 
 import (
 	"fmt"
+	"math"
+	"math/rand/v2"
 	"testing"
 )
 
@@ -103,7 +105,7 @@ func TestRandomIntValidation(t *testing.T) {
 		{0, 10, false},
 		{-5, 5, false},
 		{10, 0, true}, // min > max
-		{5, 5, true},  // min == max
+		{5, 5, false}, // min == max is a single value
 		{-10, -5, false},
 	}
 
@@ -172,6 +174,220 @@ func TestRandomIntRange(t *testing.T) {
 	}
 }
 
+func TestRandomIntEndpoints(t *testing.T) {
+	seed := NewSeed("endpoints_test")
+	const iterations = 30000
+	counts := make([]int, 3)
+
+	for i := 0; i < iterations; i++ {
+		val, _ := seed.RandomInt(0, 2)
+		counts[val]++
+	}
+
+	// Every value should get about a third of the draws, endpoints included
+	for val, count := range counts {
+		if count < iterations/3-iterations/30 || count > iterations/3+iterations/30 {
+			t.Errorf("RandomInt(0, 2) returned %d %d times out of %d", val, count, iterations)
+		}
+	}
+}
+
+func TestRandomIntSingleValue(t *testing.T) {
+	seed := NewSeed("single_test")
+
+	val, err := seed.RandomInt(7, 7)
+	if err != nil || val != 7 {
+		t.Errorf("RandomInt(7, 7) = %d, %v, want 7, nil", val, err)
+	}
+}
+
+func TestIntn(t *testing.T) {
+	seed := NewSeed("intn_test")
+
+	for _, n := range []int{1, 2, 3, 10, 1 << 40} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				val, err := seed.Intn(n)
+				if err != nil {
+					t.Fatalf("Intn(%d) returned error: %v", n, err)
+				}
+				if val < 0 || val >= n {
+					t.Fatalf("Intn(%d) = %d, out of range", n, val)
+				}
+			}
+		})
+	}
+
+	for _, n := range []int{0, -1} {
+		if _, err := seed.Intn(n); err == nil {
+			t.Errorf("Intn(%d) expected error but got none", n)
+		}
+	}
+}
+
+func TestPick(t *testing.T) {
+	seed := NewSeed("pick_test")
+	items := []string{"OwO", "UwU", ">w<"}
+	seen := map[string]bool{}
+
+	for i := 0; i < 100; i++ {
+		item, err := seed.Pick(items)
+		if err != nil {
+			t.Fatalf("Pick() returned error: %v", err)
+		}
+		seen[item] = true
+	}
+	if len(seen) != len(items) {
+		t.Errorf("Pick() only returned %v", seen)
+	}
+
+	if item, err := seed.Pick([]string{"only"}); err != nil || item != "only" {
+		t.Errorf("Pick() of a single item = %q, %v", item, err)
+	}
+	if _, err := seed.Pick(nil); err == nil {
+		t.Error("Pick(nil) expected error but got none")
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4, 5, 6, 7}
+	shuffled := make([]int, len(items))
+	copy(shuffled, items)
+
+	seed := NewSeed("shuffle_test")
+	seed.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	sum, moved := 0, false
+	for i, val := range shuffled {
+		sum += val
+		if val != items[i] {
+			moved = true
+		}
+	}
+	if sum != 28 || !moved {
+		t.Errorf("Shuffle() = %v, want a permutation of %v", shuffled, items)
+	}
+
+	// The same seed shuffles the same way
+	again := make([]int, len(items))
+	copy(again, items)
+	seed = NewSeed("shuffle_test")
+	seed.Shuffle(len(again), func(i, j int) {
+		again[i], again[j] = again[j], again[i]
+	})
+	if fmt.Sprint(again) != fmt.Sprint(shuffled) {
+		t.Errorf("Shuffle() not deterministic: %v != %v", again, shuffled)
+	}
+}
+
+func TestWeightedPick(t *testing.T) {
+	seed := NewSeed("weighted_test")
+	counts := make([]int, 3)
+
+	for i := 0; i < 10000; i++ {
+		idx, err := seed.WeightedPick([]float64{8, 0, 2})
+		if err != nil {
+			t.Fatalf("WeightedPick() returned error: %v", err)
+		}
+		counts[idx]++
+	}
+
+	if counts[1] != 0 {
+		t.Errorf("index with weight 0 was picked %d times", counts[1])
+	}
+	if counts[0] < 3*counts[2] {
+		t.Errorf("picked %v, want roughly 8:0:2", counts)
+	}
+
+	invalid := [][]float64{nil, {0, 0}, {1, -1}, {math.NaN()}, {math.Inf(1)}}
+	for _, weights := range invalid {
+		if _, err := seed.WeightedPick(weights); err == nil {
+			t.Errorf("WeightedPick(%v) expected error but got none", weights)
+		}
+	}
+}
+
+func TestSeedAsSource(t *testing.T) {
+	r1 := rand.New(NewSeed("source_test"))
+	r2 := rand.New(NewSeed("source_test"))
+
+	for i := 0; i < 10; i++ {
+		val1, val2 := r1.IntN(1000), r2.IntN(1000)
+		if val1 != val2 {
+			t.Fatalf("Call %d: rand.Rand over Seed not deterministic: %d != %d", i, val1, val2)
+		}
+	}
+}
+
+func TestSeedMarshalBinary(t *testing.T) {
+	seed := NewSeed("marshal_test")
+	seed.Random(0, 1)
+
+	data, err := seed.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() returned error: %v", err)
+	}
+
+	var restored Seed
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() returned error: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		if val1, val2 := seed.Uint64(), restored.Uint64(); val1 != val2 {
+			t.Fatalf("Call %d: restored seed diverged: %d != %d", i, val1, val2)
+		}
+	}
+
+	invalid := [][]byte{nil, data[:len(data)-1], append([]byte{99}, data[1:]...)}
+	for _, data := range invalid {
+		if err := restored.UnmarshalBinary(data); err == nil {
+			t.Errorf("UnmarshalBinary(%v) expected error but got none", data)
+		}
+	}
+}
+
+func TestSeedConstructors(t *testing.T) {
+	fromString, _ := NewSeed("constructor_test").Random(0, 1)
+	fromBytes, _ := NewSeedFromBytes([]byte("constructor_test")).Random(0, 1)
+	if fromString != fromBytes {
+		t.Errorf("NewSeedFromBytes() = %f, want %f like NewSeed()", fromBytes, fromString)
+	}
+
+	if NewSeedFromUint64(42).Uint64() != NewSeedFromUint64(42).Uint64() {
+		t.Error("NewSeedFromUint64() not deterministic")
+	}
+	if NewSeedFromUint64(42).Uint64() == NewSeedFromUint64(43).Uint64() {
+		t.Error("NewSeedFromUint64() gave the same stream for different seeds")
+	}
+}
+
+func TestSeedFork(t *testing.T) {
+	parent := NewSeed("fork_test")
+	before, _ := parent.MarshalBinary()
+
+	first := parent.Fork("faces").Uint64()
+	if parent.Fork("faces").Uint64() != first {
+		t.Error("Fork() not deterministic")
+	}
+	if parent.Fork("actions").Uint64() == first {
+		t.Error("Fork() gave the same stream for different labels")
+	}
+
+	after, _ := parent.MarshalBinary()
+	if string(before) != string(after) {
+		t.Error("Fork() changed the parent state")
+	}
+
+	// Forks depend on the parent state, not just the label
+	parent.Uint64()
+	if parent.Fork("faces").Uint64() == first {
+		t.Error("Fork() ignored the parent state")
+	}
+}
+
 func TestSeedStateProgression(t *testing.T) {
 	seed := NewSeed("progression_test")
 
@@ -0,0 +1,131 @@
+package gouwu
+
+import (
+	"math/rand/v2"
+	"sync"
+)
+
+// Generator produces the random numbers used to transform a single word.
+// Seed is the Generator behind the default, deterministic source.
+type Generator interface {
+	// Float64 returns a number in [0,1)
+	Float64() float64
+	// Uint32 returns 32 random bits
+	Uint32() uint32
+}
+
+// RandomSource decides where the random numbers used to transform each word
+// come from
+type RandomSource interface {
+	// Generator returns the generator for word. It is called once for every
+	// stage that transforms the word, with the word as that stage sees it.
+	Generator(word string) Generator
+}
+
+// WithRandomSource sets where the uwuifier draws its random numbers from.
+// The word cache is only used with the default per-word source, since other
+// sources need not give the same output twice.
+func WithRandomSource(source RandomSource) Option {
+	return func(u *Uwuifier) {
+		// The default source is handled inline
+		if _, ok := source.(perWordSource); ok {
+			source = nil
+		}
+		u.source = source
+		u.changed()
+	}
+}
+
+// perWordSource seeds every word with its own text
+type perWordSource struct{}
+
+// PerWordSource returns the default source, which seeds a Seed with each
+// word so the same word is always transformed the same way
+func PerWordSource() RandomSource { return perWordSource{} }
+
+func (perWordSource) Generator(word string) Generator { return NewSeed(word) }
+
+// nondeterministicSource seeds every word from the global generator
+type nondeterministicSource struct{}
+
+// NondeterministicSource returns a source that seeds every word randomly, so
+// the same sentence is transformed differently each time
+func NondeterministicSource() RandomSource { return nondeterministicSource{} }
+
+func (nondeterministicSource) Generator(string) Generator {
+	return NewSeedFromUint64(rand.Uint64())
+}
+
+// scriptedSource replays a fixed list of values
+type scriptedSource struct {
+	mu     sync.Mutex
+	values []float64
+	next   int
+}
+
+// ScriptedSource returns a source that hands out values in order, starting
+// over once they run out, regardless of the word. It is meant for testing
+// custom configurations: a value of 0 always triggers a transformation and
+// a value close to 1 never does. Values must be in [0,1).
+func ScriptedSource(values ...float64) RandomSource {
+	return &scriptedSource{values: values}
+}
+
+func (s *scriptedSource) Generator(string) Generator { return s }
+
+func (s *scriptedSource) Float64() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.values) == 0 {
+		return 0
+	}
+	value := s.values[s.next]
+	s.next = (s.next + 1) % len(s.values)
+	return value
+}
+
+func (s *scriptedSource) Uint32() uint32 {
+	return uint32(s.Float64() * 4294967296.0)
+}
+
+// wordRand draws the random numbers for one word, either from a Seed kept
+// inline so the default source never allocates, or from a custom Generator
+type wordRand struct {
+	seed Seed
+	gen  Generator
+}
+
+// randFor returns the random numbers for word
+func (u *Uwuifier) randFor(word []byte) wordRand {
+	if u.source != nil {
+		return wordRand{gen: u.source.Generator(string(word))}
+	}
+	return wordRand{seed: seedFor(word)}
+}
+
+// float returns a number in [0,1)
+func (r *wordRand) float() float64 {
+	if r.gen != nil {
+		return r.gen.Float64()
+	}
+	return r.seed.Float64()
+}
+
+// intn returns a number in [0,n), every value being equally likely
+func (r *wordRand) intn(n int) int {
+	if r.gen != nil {
+		return int(uint32n(r.gen.Uint32, uint32(n)))
+	}
+	return int(uint32n(r.seed.sfc32, uint32(n)))
+}
+
+// roundedInt returns a number in [min,max] the way the original port did
+func (r *wordRand) roundedInt(min, max int) int {
+	return roundedInt(r.float(), min, max)
+}
+
+// weightedIndex returns an index in [0,n) picked by weight
+func (r *wordRand) weightedIndex(n int, total float64, weight func(int) float64) int {
+	return weightedIndex(r.float(), n, total, weight)
+}
@@ -0,0 +1,75 @@
+package gouwu
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPerWordSource(t *testing.T) {
+	uwuifier := New(WithRandomSource(PerWordSource()))
+	input := "Hello world! This is a test sentence with lots of words."
+
+	if result, expected := uwuifier.UwuifySentence(input), New().UwuifySentence(input); result != expected {
+		t.Errorf("PerWordSource gave %q, want the default %q", result, expected)
+	}
+}
+
+func TestNondeterministicSource(t *testing.T) {
+	uwuifier := New(
+		WithRandomSource(NondeterministicSource()),
+		WithSpaces(SpacesModifier{Faces: 0.3, Actions: 0.3, Stutters: 0.4}),
+	)
+	input := "Hello world! This is a test sentence with lots of words."
+
+	results := map[string]bool{}
+	for i := 0; i < 10; i++ {
+		results[uwuifier.UwuifySentence(input)] = true
+	}
+	if len(results) == 1 {
+		t.Errorf("NondeterministicSource gave the same output 10 times: %v", results)
+	}
+}
+
+func TestScriptedSource(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   []float64
+		input    string
+		expected string
+	}{
+		{"every rule applies", []float64{0}, "lover", "wuvw"},
+		{"no rule applies", []float64{0.99}, "lover", "lover"},
+		{"only the second rule applies", []float64{0.99, 0, 0.99, 0.99, 0.99, 0.99}, "lover", "wovew"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uwuifier := New(WithWords(0.5), WithRandomSource(ScriptedSource(tc.values...)))
+			if result := uwuifier.UwuifyWords(tc.input); result != tc.expected {
+				t.Errorf("UwuifyWords(%q) = %q, want %q", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestScriptedSourcePicksExpressions(t *testing.T) {
+	// The first value triggers a face, the second picks the last one
+	uwuifier := New(
+		WithSpaces(SpacesModifier{Faces: 0.5}),
+		WithFaces("OwO", "UwU", ">w<"),
+		WithRandomSource(ScriptedSource(0, 0.9)),
+	)
+
+	if result := uwuifier.UwuifySpaces("hello"); !strings.HasSuffix(result, ">w<") {
+		t.Errorf("UwuifySpaces() = %q, want the last face", result)
+	}
+}
+
+func TestCustomSourceSkipsCache(t *testing.T) {
+	uwuifier := New(WithCache(16), WithRandomSource(NondeterministicSource()))
+	uwuifier.UwuifySentence("hello hello")
+
+	if stats := uwuifier.CacheStats(); stats.Hits+stats.Misses != 0 {
+		t.Errorf("custom source used the cache: %+v", stats)
+	}
+}
@@ -0,0 +1,134 @@
+package gouwu
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxStutterCount is the most times a stutter may repeat the start of a word
+const maxStutterCount = 8
+
+// WithStutterCounts weighs how many times stutters repeat the start of a
+// word, see SetStutterCounts
+func WithStutterCounts(weights ...float64) Option {
+	return func(u *Uwuifier) {
+		u.SetStutterCounts(weights...)
+	}
+}
+
+// StutterCounts returns the weights of the stutter counts, or nil if the
+// algorithm version decides
+func (u *Uwuifier) StutterCounts() []float64 { return slices.Clone(u.stutterCounts) }
+
+// SetStutterCounts weighs how many times stutters repeat the start of a
+// word, weights[i] being the weight of i repetitions: 0, 1, 3 never leaves
+// a stutter out and repeats three times as often twice as once. No weights
+// keep the default of the algorithm version, where 0 to 2 repetitions are
+// equally likely.
+func (u *Uwuifier) SetStutterCounts(weights ...float64) error {
+	if len(weights) > maxStutterCount+1 {
+		return errors.New("stutter counts must not go above 8")
+	}
+
+	var total float64
+	for _, weight := range weights {
+		if weight < 0 || math.IsInf(weight, 0) || math.IsNaN(weight) {
+			return errors.New("stutter count weights must be finite and not negative")
+		}
+		total += weight
+	}
+	if len(weights) > 0 && total == 0 {
+		return errors.New("stutter count weights must not all be 0")
+	}
+
+	u.stutterCounts = slices.Clone(weights)
+	u.changed()
+	return nil
+}
+
+// canStutter reports whether word has a start a stutter can repeat
+func (u *Uwuifier) canStutter(word []byte) bool {
+	if u.algorithm < AlgorithmV3 {
+		return !u.asciiOnly || word[0] < utf8.RuneSelf
+	}
+
+	start, end := stutterOnset(word)
+	return start < end && (!u.asciiOnly || word[start] < utf8.RuneSelf)
+}
+
+// appendStutter appends word to dst with its start repeated
+func (u *Uwuifier) appendStutter(dst, word []byte, r *wordRand) []byte {
+	var count int
+	switch {
+	case len(u.stutterCounts) > 0:
+		weights := u.stutterCounts
+		var total float64
+		for _, weight := range weights {
+			total += weight
+		}
+		count = r.weightedIndex(len(weights), total, func(i int) float64 { return weights[i] })
+	case u.algorithm == AlgorithmV1:
+		count = r.roundedInt(0, 2)
+	default:
+		count = r.intn(3)
+	}
+
+	// Versions 1 and 2 read the first byte as a Latin-1 rune as the
+	// original port did
+	if u.algorithm < AlgorithmV3 {
+		for range count {
+			dst = utf8.AppendRune(dst, rune(word[0]))
+			dst = append(dst, '-')
+		}
+		return append(dst, word...)
+	}
+
+	start, end := stutterOnset(word)
+	dst = append(dst, word[:start]...)
+	for range count {
+		dst = append(dst, word[start:end]...)
+		dst = append(dst, '-')
+	}
+	return append(dst, word[start:]...)
+}
+
+// stutterOnset returns where the part of word a stutter repeats starts and
+// ends: the consonants before its first vowel, like "th" in "this" or "str"
+// in "string", or its first letter if it starts with a vowel or has none.
+// Leading punctuation is skipped, and words not starting with a letter,
+// like numbers, have nothing to repeat.
+func stutterOnset(word []byte) (int, int) {
+	start, end := wordCore(word)
+	first, size := utf8.DecodeRune(word[start:end])
+	if !unicode.IsLetter(first) {
+		return start, start
+	}
+
+	onset := start + size
+	if isOnsetVowel(first, true) {
+		return start, onset
+	}
+	for i := onset; i < end; {
+		r, size := utf8.DecodeRune(word[i:end])
+		switch {
+		case isOnsetVowel(r, false):
+			return start, i
+		case !unicode.IsLetter(r):
+			return start, onset
+		}
+		i += size
+	}
+	return start, onset
+}
+
+// isOnsetVowel reports whether r ends the onset of a word: a vowel, a y
+// after the first letter, or any letter outside ASCII
+func isOnsetVowel(r rune, first bool) bool {
+	if r >= utf8.RuneSelf {
+		return unicode.IsLetter(r)
+	}
+	return isVowel(byte(r)) || !first && byte(r)|0x20 == 'y'
+}
@@ -0,0 +1,103 @@
+package gouwu
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStutterOnset(t *testing.T) {
+	testCases := []struct {
+		word     string
+		expected string
+	}{
+		{"this", "th"},
+		{"This", "Th"},
+		{"string", "str"},
+		{"hello", "h"},
+		{"apple", "a"},
+		{"rhythm", "rh"},
+		{"yes", "y"},
+		{"hmm", "h"},
+		{"don't", "d"},
+		{`"quote"`, "q"},
+		{"(hello)", "h"},
+		{"Émile", "É"},
+		{"Straße", "Str"},
+		{"42", ""},
+		{"...", ""},
+		{"$5", ""},
+	}
+
+	for _, tc := range testCases {
+		start, end := stutterOnset([]byte(tc.word))
+		if onset := tc.word[start:end]; onset != tc.expected {
+			t.Errorf("stutterOnset(%q) = %q, want %q", tc.word, onset, tc.expected)
+		}
+	}
+}
+
+func TestSyllableStutters(t *testing.T) {
+	once := WithStutterCounts(0, 1)
+	twice := WithStutterCounts(0, 0, 1)
+
+	testCases := []struct {
+		name     string
+		opts     []Option
+		input    string
+		expected string
+	}{
+		{"digraph", []Option{once}, "this", "th-this"},
+		{"cluster", []Option{twice}, "String", "Str-Str-String"},
+		{"vowel", []Option{once}, "apple", "a-apple"},
+		{"punctuation", []Option{once}, `("hello")`, `("h-hello")`},
+		{"number", []Option{once}, "42", "42"},
+		{"unicode", []Option{once}, "Émile", "É-Émile"},
+		{"ascii only", []Option{once, WithASCIIOnly()}, "Émile", "Émile"},
+		{"legacy", []Option{once, WithAlgorithmVersion(AlgorithmV2)}, "this", "t-this"},
+		{"legacy punctuation", []Option{once, WithAlgorithmVersion(AlgorithmV2)}, "(hello", "(-(hello"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append([]Option{WithAlgorithmVersion(AlgorithmV3), WithSpaces(SpacesModifier{Stutters: 1})}, tc.opts...)
+			if result := New(opts...).UwuifySpaces(tc.input); result != tc.expected {
+				t.Errorf("UwuifySpaces(%q) = %q, want %q", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestStutterCounts(t *testing.T) {
+	uwuifier := New(WithSpaces(SpacesModifier{Stutters: 1}), WithStutterCounts(0, 1, 1))
+
+	counts := make(map[int]int)
+	for i := range 1000 {
+		word := strings.Repeat("b", i%7+1) + "a"
+		counts[strings.Count(uwuifier.UwuifySpaces(word), "-")]++
+	}
+	if counts[0] != 0 || counts[3] != 0 || counts[1] < 400 || counts[2] < 400 {
+		t.Errorf("stutter counts = %v, want 1 and 2 about equally often", counts)
+	}
+
+	testCases := []struct {
+		weights  []float64
+		expected string
+	}{
+		{[]float64{-1, 1}, "not negative"},
+		{[]float64{0, 0}, "not all be 0"},
+		{make([]float64, 10), "not go above 8"},
+	}
+	for _, tc := range testCases {
+		if err := uwuifier.SetStutterCounts(tc.weights...); err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("SetStutterCounts(%v) error = %v, want %q", tc.weights, err, tc.expected)
+		}
+	}
+	if counts := uwuifier.StutterCounts(); !reflect.DeepEqual(counts, []float64{0, 1, 1}) {
+		t.Errorf("StutterCounts() = %v after errors", counts)
+	}
+
+	if err := uwuifier.SetStutterCounts(); err != nil || uwuifier.StutterCounts() != nil {
+		t.Errorf("SetStutterCounts() = %v, %v, want the version default", err, uwuifier.StutterCounts())
+	}
+}
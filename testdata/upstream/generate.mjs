@@ -0,0 +1,70 @@
+// Regenerates corpus.txt from the published "uwuifier" package pinned in
+// package.json, which TestUpstreamParity holds the Go parity mode to.
+//
+//	cd testdata/upstream && npm install && npm run generate
+//
+// The script refuses to run against any other version of the module, so the
+// corpus header always names the npm release the corpus came from. Commit
+// the package-lock.json npm writes alongside the regenerated corpus.
+//
+// Every input line is run through each configuration below and each stage,
+// and written as Go-compatible quoted strings separated by tabs.
+import { readFileSync, writeFileSync } from "node:fs";
+import { dirname, join } from "node:path";
+import { fileURLToPath } from "node:url";
+import Uwuifier from "uwuifier";
+
+// packageVersion returns the version in the package.json of the resolved
+// uwuifier module
+function packageVersion() {
+  for (let dir = dirname(fileURLToPath(import.meta.resolve("uwuifier"))); ; dir = dirname(dir)) {
+    try {
+      const pkg = JSON.parse(readFileSync(join(dir, "package.json"), "utf8"));
+      if (pkg.name === "uwuifier") {
+        return pkg.version;
+      }
+    } catch {}
+    if (dir === dirname(dir)) {
+      throw new Error("no package.json found for uwuifier");
+    }
+  }
+}
+
+// Keep in sync with upstreamConfigs in upstream_test.go
+const configs = {
+  default: { spaces: { faces: 0.05, actions: 0.075, stutters: 0.1 }, words: 1, exclamations: 1 },
+  partial: { spaces: { faces: 0.3, actions: 0.3, stutters: 0.3 }, words: 0.5, exclamations: 0.5 },
+  faces: { spaces: { faces: 0.9, actions: 0, stutters: 0 }, words: 0.8, exclamations: 1 },
+  stutters: { spaces: { faces: 0, actions: 0, stutters: 0.9 }, words: 0.3, exclamations: 0 },
+};
+
+const stages = {
+  sentence: (uwuifier, input) => uwuifier.uwuifySentence(input),
+  words: (uwuifier, input) => uwuifier.uwuifyWords(input),
+  exclamations: (uwuifier, input) => uwuifier.uwuifyExclamations(input),
+  spaces: (uwuifier, input) => uwuifier.uwuifySpaces(input),
+};
+
+const dir = new URL(".", import.meta.url);
+
+const pinned = JSON.parse(readFileSync(new URL("package.json", dir), "utf8")).dependencies.uwuifier;
+const version = packageVersion();
+if (version !== pinned) {
+  throw new Error(`resolved uwuifier ${version}, want the published ${pinned} pinned in package.json (run npm install)`);
+}
+const inputs = readFileSync(new URL("inputs.txt", dir), "utf8").split("\n").filter((line) => line !== "");
+
+const lines = [
+  `# generated from uwuifier ${version}`,
+  "# config\tstage\tinput\toutput (Go-quoted, tab separated)",
+];
+for (const [name, config] of Object.entries(configs)) {
+  const uwuifier = new Uwuifier(config);
+  for (const input of inputs) {
+    for (const [stage, run] of Object.entries(stages)) {
+      lines.push([name, stage, JSON.stringify(input), JSON.stringify(run(uwuifier, input))].join("\t"));
+    }
+  }
+}
+
+writeFileSync(new URL("corpus.txt", dir), lines.join("\n") + "\n");
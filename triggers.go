@@ -0,0 +1,137 @@
+package gouwu
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Trigger adds an action after words matching one of its keywords, like
+// "*yawns*" after "sleep". Keywords match ignoring case and surrounding
+// punctuation, like dictionary words.
+type Trigger struct {
+	Keywords    []string `json:"keywords"`
+	Action      string   `json:"action"`
+	Probability float64  `json:"probability"`
+}
+
+// DefaultTriggers returns a few example triggers
+func DefaultTriggers() []Trigger {
+	return []Trigger{
+		{Keywords: []string{"hug", "hugs", "cuddle", "cuddles"}, Action: "*huggles tightly*", Probability: 0.5},
+		{Keywords: []string{"sleep", "sleepy", "tired", "bed", "nap"}, Action: "*yawns*", Probability: 0.5},
+		{Keywords: []string{"food", "hungry", "eat", "eating", "snack", "lunch", "dinner"}, Action: "*nom nom*", Probability: 0.5},
+		{Keywords: []string{"pat", "pats", "headpat", "headpats"}, Action: "*purrs*", Probability: 0.5},
+	}
+}
+
+// WithTriggers sets the keyword triggered actions, see SetTriggers
+func WithTriggers(triggers ...Trigger) Option {
+	return func(u *Uwuifier) {
+		u.SetTriggers(triggers...)
+	}
+}
+
+// WithTriggerLimit caps how many triggered actions a sentence gets
+func WithTriggerLimit(limit int) Option {
+	return func(u *Uwuifier) {
+		u.SetTriggerLimit(limit)
+	}
+}
+
+// Triggers returns a copy of the keyword triggered actions
+func (u *Uwuifier) Triggers() []Trigger {
+	triggers := slices.Clone(u.triggers)
+	for i := range triggers {
+		triggers[i].Keywords = slices.Clone(triggers[i].Keywords)
+	}
+	return triggers
+}
+
+// TriggerLimit returns how many triggered actions a sentence gets at most
+func (u *Uwuifier) TriggerLimit() int { return u.triggerLimit }
+
+// SetTriggers replaces the keyword triggered actions. In the spaces stage a
+// word matching a keyword is followed by the action of the first trigger it
+// matches with the trigger's probability, instead of a random face, action
+// or stutter, until the sentence reaches the trigger limit. Actions the
+// rating does not allow are never triggered.
+func (u *Uwuifier) SetTriggers(triggers ...Trigger) error {
+	index := make(map[string]int)
+	for i, trigger := range triggers {
+		if trigger.Action == "" {
+			return errors.New("trigger action must not be empty")
+		}
+		if trigger.Probability < 0 || trigger.Probability > 1 {
+			return fmt.Errorf("trigger %q: probability must be between 0 and 1", trigger.Action)
+		}
+		if len(trigger.Keywords) == 0 {
+			return fmt.Errorf("trigger %q: keywords must not be empty", trigger.Action)
+		}
+		for _, keyword := range trigger.Keywords {
+			if err := checkWord("trigger", keyword); err != nil {
+				return fmt.Errorf("trigger %q: %w", trigger.Action, err)
+			}
+			if _, ok := index[strings.ToLower(keyword)]; !ok {
+				index[strings.ToLower(keyword)] = i
+			}
+		}
+	}
+	if len(index) == 0 {
+		index = nil
+	}
+
+	u.triggers = slices.Clone(triggers)
+	for i := range u.triggers {
+		u.triggers[i].Keywords = slices.Clone(u.triggers[i].Keywords)
+	}
+	u.triggerIndex = index
+	u.changed()
+	return nil
+}
+
+// SetTriggerLimit caps how many triggered actions a sentence gets. The
+// default is 1 and a limit of 0 turns triggers off.
+func (u *Uwuifier) SetTriggerLimit(limit int) error {
+	if limit < 0 {
+		return errors.New("trigger limit must not be negative")
+	}
+	u.triggerLimit = limit
+	u.changed()
+	return nil
+}
+
+// triggerFor returns the index of the trigger sc.word matches, or -1
+func (u *Uwuifier) triggerFor(sc *scratch) int {
+	if len(u.triggerIndex) == 0 {
+		return -1
+	}
+
+	start, end := wordCore(sc.word)
+	if start == end {
+		return -1
+	}
+
+	sc.fold = appendFold(sc.fold[:0], sc.word[start:end])
+	if i, ok := u.triggerIndex[string(sc.fold)]; ok {
+		return i
+	}
+	return -1
+}
+
+// appendTriggered appends word and tail to dst followed by the action of
+// trigger i if it fires, and reports whether it did
+func (u *Uwuifier) appendTriggered(dst, word, tail []byte, i int) ([]byte, bool) {
+	trigger := u.triggers[i]
+
+	r := u.randFor(word)
+	if r.float() > trigger.Probability || isBreak(word) || !u.filter().allows(trigger.Action) {
+		return dst, false
+	}
+
+	dst = append(dst, word...)
+	dst = append(dst, tail...)
+	dst = append(dst, ' ')
+	return append(dst, trigger.Action...), true
+}
@@ -0,0 +1,117 @@
+package gouwu
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTriggers(t *testing.T) {
+	uwuifier := New(
+		WithWords(1),
+		WithSpaces(SpacesModifier{}),
+		WithTriggers(Trigger{Keywords: []string{"sleep", "nap"}, Action: "*yawns*", Probability: 1}),
+	)
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"time to sleep", "time to sweep *yawns*"},
+		{"Nap.", "nyap. *yawns*"},
+		{"sleeping now", "sweeping nyow"},
+	}
+
+	for _, tc := range testCases {
+		if result := uwuifier.UwuifySentence(tc.input); result != tc.expected {
+			t.Errorf("UwuifySentence(%q) = %q, want %q", tc.input, result, tc.expected)
+		}
+	}
+}
+
+func TestTriggerLimit(t *testing.T) {
+	input := "hug hug hug hug"
+	uwuifier := New(
+		WithSpaces(SpacesModifier{}),
+		WithTriggers(Trigger{Keywords: []string{"hug"}, Action: "*huggles tightly*", Probability: 1}),
+	)
+
+	if count := strings.Count(uwuifier.UwuifySpaces(input), "*huggles tightly*"); count != 1 {
+		t.Errorf("default limit triggered %d actions, want 1", count)
+	}
+
+	uwuifier.SetTriggerLimit(3)
+	if count := strings.Count(uwuifier.UwuifySpaces(input), "*huggles tightly*"); count != 3 {
+		t.Errorf("limit 3 triggered %d actions, want 3", count)
+	}
+
+	uwuifier.SetTriggerLimit(0)
+	if count := strings.Count(uwuifier.UwuifySpaces(input), "*huggles tightly*"); count != 0 {
+		t.Errorf("limit 0 triggered %d actions, want 0", count)
+	}
+
+	if err := uwuifier.SetTriggerLimit(-1); err == nil {
+		t.Error("SetTriggerLimit(-1) should have errored")
+	}
+}
+
+func TestTriggerProbability(t *testing.T) {
+	uwuifier := New(
+		WithSpaces(SpacesModifier{}),
+		WithTriggers(Trigger{Keywords: []string{"food"}, Action: "*nom nom*", Probability: 0.5}),
+	)
+
+	fired := 0
+	for i := 0; i < 1000; i++ {
+		// Punctuation changes the seed but not the keyword
+		word := "food" + strings.Repeat(".", i%50) + strings.Repeat("!", i/50)
+		if strings.Contains(uwuifier.UwuifySpaces(word), "*nom nom*") {
+			fired++
+		}
+	}
+	if fired < 400 || fired > 600 {
+		t.Errorf("trigger with probability 0.5 fired %d times out of 1000", fired)
+	}
+}
+
+func TestTriggersWithCache(t *testing.T) {
+	trigger := WithTriggers(Trigger{Keywords: []string{"hug"}, Action: "*huggles tightly*", Probability: 1})
+	cached := New(WithCache(64), trigger)
+	plain := New(trigger)
+
+	for _, input := range []string{"hug", "hug hug", "a hug for you and a hug for me", "hug"} {
+		if result, expected := cached.UwuifySentence(input), plain.UwuifySentence(input); result != expected {
+			t.Errorf("UwuifySentence(%q) = %q, want %q", input, result, expected)
+		}
+	}
+}
+
+func TestTriggersRespectRating(t *testing.T) {
+	uwuifier := New(
+		WithSpaces(SpacesModifier{}),
+		WithTriggers(Trigger{Keywords: []string{"dance"}, Action: "*twerks*", Probability: 1}),
+		WithRating(RatingSafe),
+	)
+
+	if result := uwuifier.UwuifySpaces("dance"); result != "dance" {
+		t.Errorf("UwuifySpaces(%q) = %q, want no suggestive trigger", "dance", result)
+	}
+}
+
+func TestTriggerValidation(t *testing.T) {
+	testCases := []Trigger{
+		{Keywords: []string{"hug"}, Probability: 1},
+		{Keywords: []string{"hug"}, Action: "*hugs*", Probability: 2},
+		{Action: "*hugs*", Probability: 1},
+		{Keywords: []string{"two words"}, Action: "*hugs*", Probability: 1},
+	}
+
+	for _, trigger := range testCases {
+		if err := New().SetTriggers(trigger); err == nil {
+			t.Errorf("SetTriggers(%+v) should have errored", trigger)
+		}
+	}
+
+	if err := New().SetTriggers(DefaultTriggers()...); err != nil {
+		t.Errorf("SetTriggers(DefaultTriggers()) error = %v", err)
+	}
+}
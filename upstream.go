@@ -0,0 +1,322 @@
+package gouwu
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// upstreamMap holds the replacement rules of the original TypeScript
+// Uwuifier in its order, which applies "ove" last and maps "NO" to "NyO"
+var upstreamMap = []UwuReplacement{
+	classRule("rl", 'w'),
+	classRule("RL", 'W'),
+	pairRule('n', "aeiou", "ny"),
+	pairRule('N', "aeiou", "Ny"),
+	pairRule('N', "AEIOU", "Ny"),
+	literalRule("ove", "uv"),
+}
+
+// WithUpstreamParity makes the uwuifier follow the algorithm of the
+// TypeScript Uwuifier (https://github.com/Schotsl/Uwuifier) that gouwu was
+// ported from. See SetUpstreamParity for what it covers and how it is checked.
+func WithUpstreamParity() Option {
+	return func(u *Uwuifier) {
+		u.SetUpstreamParity(true)
+	}
+}
+
+// UpstreamParity reports whether the uwuifier follows the TypeScript Uwuifier
+func (u *Uwuifier) UpstreamParity() bool { return u.parity }
+
+// SetUpstreamParity switches upstream parity on or off.
+//
+// In parity mode words are seeded from their UTF-16 code units like the
+// JavaScript strings upstream hashes, the upstream rules are applied in
+// upstream order, choices are made with the rounded RandomInt of algorithm
+// version 1, stutters repeat the first character rather than the first byte,
+// letters without an uppercase form count as capitals, and the capitalization
+// check looks at the previous word before faces and actions were added to it.
+//
+// Parity mode uses upstream's rules, seeding, selection and capitalization
+// regardless of the algorithm version, random source, weights, stutter
+// counts, capitalization policy and cache, and ignores custom rules, the
+// dictionary, protected words, moods, triggers, elongation, the catgirl
+// stage, owoify levels and the phonetic pack. A rating limit and the
+// ASCII-only guarantee still apply, as if the expressions they reject were
+// not in the lists, and so does the accessibility mode. Faces, actions,
+// exclamations and modifiers are still taken from the uwuifier. Upstream
+// throws on empty words and empty expression lists in some configurations;
+// gouwu leaves those untouched instead.
+//
+// The mode is checked against testdata/upstream/corpus.txt, which is
+// generated by testdata/upstream/generate.mjs from the uwuifier release
+// pinned in testdata/upstream/package.json. The corpus header names the
+// version it came from.
+func (u *Uwuifier) SetUpstreamParity(enabled bool) {
+	u.parity = enabled
+	u.changed()
+}
+
+// appendUpstream is appendUwuify for upstream parity mode
+func appendUpstream[T text](u *Uwuifier, dst []byte, src T, stages stage) []byte {
+	sc := scratchPool.Get().(*scratch)
+	defer scratchPool.Put(sc)
+
+	// The capitalization check looks at the previous word as the spaces
+	// stage received it
+	sc.prev = sc.prev[:0]
+
+	for i, start := 0, 0; start <= len(src); i++ {
+		end := start
+		for end < len(src) && src[end] != ' ' {
+			end++
+		}
+
+		if i > 0 {
+			dst = append(dst, ' ')
+		}
+
+		sc.word = append(sc.word[:0], src[start:end]...)
+		if stages&stageWords != 0 {
+			u.upstreamWord(sc)
+		}
+		if stages&stageExclamations != 0 {
+			u.upstreamExclamation(sc)
+		}
+		if stages&stageSpaces != 0 {
+			dst = u.appendUpstreamSpaced(dst, sc, i)
+		} else {
+			dst = append(dst, sc.word...)
+		}
+
+		sc.prev = append(sc.prev[:0], sc.word...)
+		start = end + 1
+	}
+
+	return dst
+}
+
+// upstreamWord applies the upstream replacement rules to sc.word
+func (u *Uwuifier) upstreamWord(sc *scratch) {
+	if isAt(sc.word) || isURI(sc.word) {
+		return
+	}
+
+	seed := upstreamSeed(sc.word)
+
+	for _, replacement := range upstreamMap {
+		if seed.Float64() > u.wordsModifier {
+			continue
+		}
+
+		sc.spare = replacement.apply(sc.spare[:0], sc.word)
+		sc.word, sc.spare = sc.spare, sc.word
+	}
+}
+
+// upstreamExclamation replaces the exclamation ending sc.word like upstream
+func (u *Uwuifier) upstreamExclamation(sc *scratch) {
+	seed := upstreamSeed(sc.word)
+
+	f := u.filter()
+	n := trailingExclamation(sc.word)
+	if n == 0 || seed.Float64() > u.exclamationsModifier || f.count(u.Exclamations) == 0 {
+		return
+	}
+
+	exclamation := upstreamPick(&seed, u.Exclamations, f)
+	sc.word = append(sc.word[:len(sc.word)-n], exclamation...)
+}
+
+// appendUpstreamSpaced appends sc.word to dst with a face, action or stutter
+// added like upstream. index is the position of the word in the sentence.
+func (u *Uwuifier) appendUpstreamSpaced(dst []byte, sc *scratch, index int) []byte {
+	word := sc.word
+	if len(word) == 0 {
+		return dst
+	}
+
+	faceThreshold := u.spacesModifier.Faces
+	actionThreshold := u.spacesModifier.Actions + faceThreshold
+	stutterThreshold := u.spacesModifier.Stutters + actionThreshold
+
+	seed := upstreamSeed(word)
+	randVal := seed.Float64()
+	firstChar, size := utf8.DecodeRune(word)
+	f := u.filter()
+
+	var insert string
+	var face bool
+	switch {
+	case randVal <= faceThreshold && f.count(u.Faces) > 0:
+		if u.accessibility == AccessibilityOmit {
+			return append(dst, word...)
+		}
+		insert = upstreamPick(&seed, u.Faces, f)
+		face = true
+	case randVal <= actionThreshold && f.count(u.Actions) > 0:
+		insert = upstreamPick(&seed, u.Actions, f)
+	case randVal <= stutterThreshold && !isURI(word) && (!u.asciiOnly || firstChar < utf8.RuneSelf) &&
+		u.accessibility == 0:
+		for range roundedInt(seed.Float64(), 0, 2) {
+			dst = utf8.AppendRune(dst, firstChar)
+			dst = append(dst, '-')
+		}
+		return append(dst, word...)
+	default:
+		return append(dst, word...)
+	}
+
+	start := len(dst)
+	dst = append(dst, word...)
+	dst = append(dst, ' ')
+	if face {
+		dst = u.appendFace(dst, insert)
+	} else {
+		dst = append(dst, insert...)
+	}
+
+	if !upstreamLowerFirst(dst[start:], firstChar, index, sc.prev) ||
+		u.asciiOnly && firstChar >= utf8.RuneSelf {
+		return dst
+	}
+
+	// JavaScript slices off a single UTF-16 code unit, which leaves the
+	// second half of a surrogate pair behind
+	sc.spare = append(sc.spare[:0], dst[start+size:]...)
+	dst = appendUpstreamLower(dst[:start], firstChar)
+	if firstChar > 0xFFFF {
+		dst = utf8.AppendRune(dst, utf8.RuneError)
+	}
+	return append(dst, sc.spare...)
+}
+
+// upstreamPick picks from the expressions in list that f allows with the
+// rounded RandomInt of upstream
+func upstreamPick(seed *Seed, list []string, f filter) string {
+	return f.nth(list, roundedInt(seed.Float64(), 0, f.count(list)-1))
+}
+
+// upstreamLowerFirst is the upstream checkCapital
+func upstreamLowerFirst(word []byte, firstChar rune, index int, prev []byte) bool {
+	if !upstreamIsUpper(firstChar) {
+		return false
+	}
+
+	var totalLetters, upperLetters int
+	for i := 0; i < len(word); {
+		r, size := utf8.DecodeRune(word[i:])
+		i += size
+
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		if upstreamIsUpper(r) {
+			upperLetters++
+		}
+		totalLetters++
+	}
+	if totalLetters > 0 && float64(upperLetters)/float64(totalLetters) > 0.5 {
+		return false
+	}
+
+	if index == 0 {
+		return true
+	}
+	if len(prev) == 0 {
+		return false
+	}
+	switch prev[len(prev)-1] {
+	case '.', '!', '?', '-':
+		return true
+	}
+	return false
+}
+
+// upstreamIsUpper reports whether r equals its JavaScript toUpperCase, which
+// holds for capitals but also for letters that have no uppercase form
+func upstreamIsUpper(r rune) bool {
+	return unicode.ToUpper(r) == r && !hasSpecialUpper(r)
+}
+
+// hasSpecialUpper reports whether r uppercases to several characters in
+// JavaScript, like "ß" to "SS", which Go's single rune mapping leaves as is
+func hasSpecialUpper(r rune) bool {
+	switch {
+	case r == 0x00DF, r == 0x0149, r == 0x01F0, r == 0x0390, r == 0x03B0, r == 0x0587:
+		return true
+	case 0x1E96 <= r && r <= 0x1E9A:
+		return true
+	case r == 0x1F50, r == 0x1F52, r == 0x1F54, r == 0x1F56:
+		return true
+	case 0x1F80 <= r && r <= 0x1FAF:
+		return true
+	case 0x1FB2 <= r && r <= 0x1FB4, r == 0x1FB6, r == 0x1FB7, r == 0x1FBC:
+		return true
+	case 0x1FC2 <= r && r <= 0x1FC4, r == 0x1FC6, r == 0x1FC7, r == 0x1FCC:
+		return true
+	case r == 0x1FD2, r == 0x1FD3, r == 0x1FD6, r == 0x1FD7:
+		return true
+	case 0x1FE2 <= r && r <= 0x1FE4, r == 0x1FE6, r == 0x1FE7:
+		return true
+	case 0x1FF2 <= r && r <= 0x1FF4, r == 0x1FF6, r == 0x1FF7, r == 0x1FFC:
+		return true
+	case 0xFB00 <= r && r <= 0xFB06, 0xFB13 <= r && r <= 0xFB17:
+		return true
+	}
+	return false
+}
+
+// appendUpstreamLower appends the JavaScript toLowerCase of r
+func appendUpstreamLower(dst []byte, r rune) []byte {
+	// The only unconditional lowercase mapping to several characters
+	if r == 'İ' {
+		return append(dst, "i̇"...)
+	}
+	return utf8.AppendRune(dst, unicode.ToLower(r))
+}
+
+// upstreamSeed seeds a generator the way upstream does, hashing the UTF-16
+// code units of word rather than its UTF-8 bytes
+func upstreamSeed(word []byte) Seed {
+	units := 0
+	for i := 0; i < len(word); {
+		r, size := utf8.DecodeRune(word[i:])
+		i += size
+		units += utf16Len(r)
+	}
+
+	var s Seed
+	h := uint32(1779033703) ^ uint32(units)
+	for i := 0; i < len(word); {
+		r, size := utf8.DecodeRune(word[i:])
+		i += size
+
+		if utf16Len(r) == 1 {
+			h = s.imul32(h^uint32(r), 3432918353)
+			h = (h << 13) | (h >> 19)
+			continue
+		}
+
+		// Split into a surrogate pair
+		r -= 0x10000
+		for _, unit := range [2]uint32{0xD800 + uint32(r>>10), 0xDC00 + uint32(r&0x3FF)} {
+			h = s.imul32(h^unit, 3432918353)
+			h = (h << 13) | (h >> 19)
+		}
+	}
+
+	s.a = s.xmur3Hash(&h)
+	s.b = s.xmur3Hash(&h)
+	s.c = s.xmur3Hash(&h)
+	s.d = s.xmur3Hash(&h)
+	return s
+}
+
+// utf16Len returns the number of UTF-16 code units needed for r
+func utf16Len(r rune) int {
+	if r > 0xFFFF {
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,128 @@
+package gouwu
+
+import (
+	"strings"
+	"testing"
+)
+
+// upstreamConfigs mirrors the configurations in testdata/upstream/generate.mjs
+var upstreamConfigs = map[string][]Option{
+	"default": {
+		WithSpaces(SpacesModifier{Faces: 0.05, Actions: 0.075, Stutters: 0.1}),
+		WithWords(1),
+		WithExclamations(1),
+	},
+	"partial": {
+		WithSpaces(SpacesModifier{Faces: 0.3, Actions: 0.3, Stutters: 0.3}),
+		WithWords(0.5),
+		WithExclamations(0.5),
+	},
+	"faces": {
+		WithSpaces(SpacesModifier{Faces: 0.9}),
+		WithWords(0.8),
+		WithExclamations(1),
+	},
+	"stutters": {
+		WithSpaces(SpacesModifier{Stutters: 0.9}),
+		WithWords(0.3),
+		WithExclamations(0),
+	},
+}
+
+func TestUpstreamParity(t *testing.T) {
+	for _, tc := range readGolden(t, "testdata/upstream/corpus.txt") {
+		opts, ok := upstreamConfigs[tc.Config]
+		if !ok {
+			t.Fatalf("unknown upstream config %q", tc.Config)
+		}
+
+		uwuifier := New(append([]Option{WithUpstreamParity()}, opts...)...)
+		checkGolden(t, uwuifier, tc)
+	}
+}
+
+func TestUpstreamParityIgnoresGoOnlySettings(t *testing.T) {
+	input := "Hello world! This is a test sentence, really?! Nobody NOTICED."
+	expected := New(WithUpstreamParity()).UwuifySentence(input)
+
+	uwuifier := New(
+		WithUpstreamParity(),
+		WithCache(16),
+		WithAlgorithmVersion(AlgorithmV2),
+		WithRandomSource(NondeterministicSource()),
+		WithFaceWeights(Weights{"UwU": 10}),
+	)
+	for range 3 {
+		if result := uwuifier.UwuifySentence(input); result != expected {
+			t.Errorf("UwuifySentence(%q) = %q, want %q", input, result, expected)
+		}
+	}
+}
+
+func TestSetUpstreamParity(t *testing.T) {
+	uwuifier := New(WithWords(1))
+	if uwuifier.UpstreamParity() {
+		t.Fatal("upstream parity is on by default")
+	}
+
+	// Upstream applies "ove" last and keeps the y of "Ny" lowercase
+	uwuifier.SetUpstreamParity(true)
+	if result := uwuifier.UwuifyWords("NOVEL"); result != "NyOVEW" {
+		t.Errorf("UwuifyWords(%q) = %q, want %q", "NOVEL", result, "NyOVEW")
+	}
+
+	uwuifier.SetUpstreamParity(false)
+	if result := uwuifier.UwuifyWords("NOVEL"); result != "NYOVEW" {
+		t.Errorf("UwuifyWords(%q) = %q, want %q", "NOVEL", result, "NYOVEW")
+	}
+}
+
+func TestUpstreamSeed(t *testing.T) {
+	// ASCII hashes the same as UTF-8 and UTF-16
+	for _, word := range []string{"", "hello", "UwU!"} {
+		seed := upstreamSeed([]byte(word))
+		if seed != *NewSeed(word) {
+			t.Errorf("upstreamSeed(%q) differs from NewSeed", word)
+		}
+	}
+
+	// Non-ASCII does not
+	word := "café🎉"
+	seed := upstreamSeed([]byte(word))
+	if seed == *NewSeed(word) {
+		t.Errorf("upstreamSeed(%q) hashed UTF-8 bytes", word)
+	}
+}
+
+func TestUpstreamStutterUsesFirstCharacter(t *testing.T) {
+	uwuifier := New(WithUpstreamParity(), WithSpaces(SpacesModifier{Stutters: 1}))
+
+	for _, word := range []string{"Émile", "über", "Ñandú", "日本"} {
+		result := uwuifier.UwuifySpaces(word)
+		first := string([]rune(word)[0])
+		if !strings.HasSuffix(result, word) || strings.Trim(result[:len(result)-len(word)], first+"-") != "" {
+			t.Errorf("UwuifySpaces(%q) = %q, want stutters of %q", word, result, first)
+		}
+	}
+}
+
+func TestUpstreamIsUpper(t *testing.T) {
+	testCases := []struct {
+		input    rune
+		expected bool
+	}{
+		{'A', true},
+		{'a', false},
+		{'1', true},  // No case mapping
+		{'世', true},  // No case mapping
+		{'ß', false}, // Uppercases to "SS"
+		{'ﬁ', false}, // Uppercases to "FI"
+		{'É', true},
+	}
+
+	for _, tc := range testCases {
+		if result := upstreamIsUpper(tc.input); result != tc.expected {
+			t.Errorf("upstreamIsUpper(%q) = %v, want %v", tc.input, result, tc.expected)
+		}
+	}
+}
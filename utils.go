@@ -1,24 +1,43 @@
 package gouwu
 
 import (
-	"regexp"
-	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
+// text is satisfied by both strings and byte slices so the helpers below can
+// run on the pipeline's []byte tokens without converting them
+type text interface {
+	~string | ~[]byte
+}
+
 // isAt checks if the value starts with '@' (for mentions/handles)
-func isAt(value string) bool {
+func isAt[T text](value T) bool {
 	if len(value) == 0 {
 		return false
 	}
 	return value[0] == '@'
 }
 
+// decodeRune decodes the rune starting at byte offset i without allocating
+func decodeRune[T text](str T, i int) (rune, int) {
+	if str[i] < utf8.RuneSelf {
+		return rune(str[i]), 1
+	}
+
+	var buf [utf8.UTFMax]byte
+	n := copy(buf[:], str[i:])
+	return utf8.DecodeRune(buf[:n])
+}
+
 // getCapitalPercentage calculates what percentage of letters in the string are uppercase
-func getCapitalPercentage(str string) float64 {
+func getCapitalPercentage[T text](str T) float64 {
 	var totalLetters, upperLetters int
 
-	for _, r := range str {
+	for i := 0; i < len(str); {
+		r, size := decodeRune(str, i)
+		i += size
+
 		if !unicode.IsLetter(r) {
 			continue
 		}
@@ -36,66 +55,133 @@ func getCapitalPercentage(str string) float64 {
 	return float64(upperLetters) / float64(totalLetters)
 }
 
+// isURIChar reports whether c may appear anywhere in a URI
+func isURIChar(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+
+	switch c {
+	case ':', '/', '?', '#', '[', ']', '@', '!', '$', '&', '\'',
+		'(', ')', '*', '+', ',', ';', '=', '.', '-', '_', '~', '%':
+		return true
+	}
+	return false
+}
+
+// isHex reports whether c is a hexadecimal digit
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
 // isURI validates if the given string is a valid URI
-// Direct port of the RFC 3986 validation logic from the original JS
-func isURI(value string) bool {
-	if value == "" {
+// Hand-written equivalent of the RFC 3986 validation logic from the original JS,
+// which splits the value with:
+//
+//	(?:([^:/?#]+):)?(?://([^/?#]*))?([^?#]*)(?:\?([^#]*))?(?:#(.*))?
+func isURI[T text](value T) bool {
+	if len(value) == 0 {
 		return false
 	}
 
 	// Check for illegal characters
-	illegalChars := regexp.MustCompile(`[^a-zA-Z0-9:/?#\[\]@!$&'()*+,;=.\-_~%]`)
-	if illegalChars.MatchString(value) {
-		return false
+	for i := 0; i < len(value); i++ {
+		if !isURIChar(value[i]) {
+			return false
+		}
 	}
 
-	// Check for incomplete hex escapes
-	incompleteHex1 := regexp.MustCompile(`%[^0-9a-fA-F]`)
-	incompleteHex2 := regexp.MustCompile(`%[0-9a-fA-F]([^0-9a-fA-F]|$)`)
-	if incompleteHex1.MatchString(value) || incompleteHex2.MatchString(value) {
-		return false
+	// Check for incomplete hex escapes: a '%' must be followed by two hex
+	// digits, although the JS patterns let a trailing '%' through
+	for i := 0; i < len(value); i++ {
+		if value[i] != '%' || i+1 == len(value) {
+			continue
+		}
+		if !isHex(value[i+1]) || i+2 == len(value) || !isHex(value[i+2]) {
+			return false
+		}
 	}
 
-	// RFC 3986 URI parsing regex - EXACTLY as in JS
-	uriRegex := regexp.MustCompile(`(?:([^:/?#]+):)?(?://([^/?#]*))?([^?#]*)(?:\?([^#]*))?(?:#(.*))?`)
-	matches := uriRegex.FindStringSubmatch(value)
+	// The scheme runs up to the first ':' as long as no '/', '?' or '#' comes first
+	rest := 0
+	schemeEnd := -1
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c == ':' {
+			if i > 0 {
+				schemeEnd = i
+				rest = i + 1
+			}
+			break
+		}
+		if c == '/' || c == '?' || c == '#' {
+			break
+		}
+	}
 
-	if matches == nil {
+	// Scheme and path are required, though the path can be empty
+	if schemeEnd < 0 {
 		return false
 	}
 
-	scheme := matches[1]
-	authority := matches[2]
-	path := matches[3]
+	// The authority follows "//" and runs up to the next '/', '?' or '#'
+	authorityLen := 0
+	if rest+1 < len(value) && value[rest] == '/' && value[rest+1] == '/' {
+		rest += 2
+		for rest+authorityLen < len(value) {
+			c := value[rest+authorityLen]
+			if c == '/' || c == '?' || c == '#' {
+				break
+			}
+			authorityLen++
+		}
+		rest += authorityLen
+	}
 
-	// Scheme and path are required, though the path can be empty
-	// EXACT JS LOGIC: scheme && scheme.length && path.length >= 0
-	if !(scheme != "" && len(path) >= 0) {
-		return false
+	// The path runs up to the query or fragment
+	pathEnd := rest
+	for pathEnd < len(value) && value[pathEnd] != '?' && value[pathEnd] != '#' {
+		pathEnd++
 	}
+	path := value[rest:pathEnd]
 
-	// If authority is present, path must be empty or start with /
-	if authority != "" {
-		if !(len(path) == 0 || strings.HasPrefix(path, "/")) {
+	if authorityLen > 0 {
+		// If authority is present, path must be empty or start with /
+		if !(len(path) == 0 || path[0] == '/') {
 			return false
 		}
 	} else {
 		// If no authority, path must not start with //
-		if strings.HasPrefix(path, "//") {
+		if len(path) >= 2 && path[0] == '/' && path[1] == '/' {
 			return false
 		}
 	}
 
 	// Scheme validation: must start with letter, then letters/digits/+/./-
-	schemeRegex := regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+\-.]*$`)
-	if !schemeRegex.MatchString(scheme) {
+	first := value[0]
+	if !('a' <= first && first <= 'z' || 'A' <= first && first <= 'Z') {
 		return false
 	}
+	for i := 1; i < schemeEnd; i++ {
+		c := value[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+			c == '+' || c == '-' || c == '.') {
+			return false
+		}
+	}
 
 	return true
 }
 
 // isBreak checks if the word is just whitespace
-func isBreak(word string) bool {
-	return strings.TrimSpace(word) == ""
+func isBreak[T text](word T) bool {
+	for i := 0; i < len(word); {
+		r, size := decodeRune(word, i)
+		if !unicode.IsSpace(r) {
+			return false
+		}
+		i += size
+	}
+	return true
 }
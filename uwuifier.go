@@ -2,8 +2,9 @@ package gouwu
 
 import (
 	"errors"
+	"maps"
 	"regexp"
-	"strings"
+	"slices"
 )
 
 // SpacesModifier defines probabilities for space transformations
@@ -17,6 +18,10 @@ type SpacesModifier struct {
 type UwuReplacement struct {
 	Pattern     *regexp.Regexp
 	Replacement string
+
+	replace replaceFunc
+	choices []string
+	locate  locateFunc
 }
 
 // Default configuration values
@@ -32,10 +37,49 @@ type Uwuifier struct {
 	Exclamations []string
 	Actions      []string
 	uwuMap       []UwuReplacement
+	rules        []UwuReplacement
+
+	phonetics     Phonetics
+	phoneticRules []UwuReplacement
+	stutterCounts []float64
+
+	capitalization Capitalization
+
+	dictionary map[string]string
+	protected  map[string]struct{}
 
 	wordsModifier        float64
 	spacesModifier       SpacesModifier
 	exclamationsModifier float64
+	elongationModifier   ElongationModifier
+	nyaModifier          NyaModifier
+
+	faceWeights        Weights
+	actionWeights      Weights
+	exclamationWeights Weights
+
+	rating    Rating
+	ratings   Ratings
+	moods     Moods
+	lexicon   Lexicon
+	asciiOnly bool
+
+	triggers     []Trigger
+	triggerIndex map[string]int
+	triggerLimit int
+
+	accessibility Accessibility
+	faceMarker    FaceMarker
+	descriptions  map[string]string
+
+	personas map[string]Persona
+	derived  *derivedSet
+	owoify   OwoifyLevel
+
+	algorithm AlgorithmVersion
+	parity    bool
+	source    RandomSource
+	cache     *wordCache
 }
 
 // Option defines a configuration function
@@ -62,34 +106,117 @@ func WithExclamations(probability float64) Option {
 	}
 }
 
+// WithFaces replaces the faces added between words
+func WithFaces(faces ...string) Option {
+	return func(u *Uwuifier) {
+		u.Faces = slices.Clone(faces)
+	}
+}
+
+// WithExtraFaces adds faces to the ones already configured
+func WithExtraFaces(faces ...string) Option {
+	return func(u *Uwuifier) {
+		u.Faces = appendExpressions(u.Faces, faces)
+	}
+}
+
+// WithoutFaces removes the given faces, or every face if none are given
+func WithoutFaces(faces ...string) Option {
+	return func(u *Uwuifier) {
+		u.Faces = removeExpressions(u.Faces, faces)
+	}
+}
+
+// WithActions replaces the actions added between words
+func WithActions(actions ...string) Option {
+	return func(u *Uwuifier) {
+		u.Actions = slices.Clone(actions)
+	}
+}
+
+// WithExtraActions adds actions to the ones already configured
+func WithExtraActions(actions ...string) Option {
+	return func(u *Uwuifier) {
+		u.Actions = appendExpressions(u.Actions, actions)
+	}
+}
+
+// WithoutActions removes the given actions, or every action if none are given
+func WithoutActions(actions ...string) Option {
+	return func(u *Uwuifier) {
+		u.Actions = removeExpressions(u.Actions, actions)
+	}
+}
+
+// WithExclamationList replaces the exclamations that replace "!" and "?"
+func WithExclamationList(exclamations ...string) Option {
+	return func(u *Uwuifier) {
+		u.Exclamations = slices.Clone(exclamations)
+	}
+}
+
+// WithExtraExclamations adds exclamations to the ones already configured
+func WithExtraExclamations(exclamations ...string) Option {
+	return func(u *Uwuifier) {
+		u.Exclamations = appendExpressions(u.Exclamations, exclamations)
+	}
+}
+
+// WithoutExclamations removes the given exclamations, or every exclamation
+// if none are given
+func WithoutExclamations(exclamations ...string) Option {
+	return func(u *Uwuifier) {
+		u.Exclamations = removeExpressions(u.Exclamations, exclamations)
+	}
+}
+
+// appendExpressions returns a new list holding list followed by the
+// expressions it does not contain yet
+func appendExpressions(list, expressions []string) []string {
+	result := slices.Clone(list)
+	for _, expression := range expressions {
+		if !slices.Contains(result, expression) {
+			result = append(result, expression)
+		}
+	}
+	return result
+}
+
+// removeExpressions returns a new list without the given expressions, or an
+// empty list if none are given
+func removeExpressions(list, expressions []string) []string {
+	if len(expressions) == 0 {
+		return []string{}
+	}
+	return slices.DeleteFunc(slices.Clone(list), func(expression string) bool {
+		return slices.Contains(expressions, expression)
+	})
+}
+
 // New creates a new Uwuifier with optional configuration
 func New(opts ...Option) *Uwuifier {
+	kaomoji, _ := LookupPack("kaomoji")
 	u := &Uwuifier{
-		Faces: []string{
-			"(・`ω´・)", ";;w;;", "OwO", "UwU", ">w<",
-			"^w^", "ÚwÚ", "^-^", ":3", "x3",
-		},
-		Exclamations: []string{"!?", "?!!", "?!?1", "!!11", "?!?!"},
-		Actions: []string{
-			"*blushes*", "*whispers to self*", "*cries*", "*screams*",
-			"*sweats*", "*twerks*", "*runs away*", "*screeches*",
-			"*walks away*", "*sees bulge*", "*looks at you*",
-			"*notices buldge*", "*starts twerking*", "*huggles tightly*",
-			"*boops your nose*",
-		},
+		Faces:                kaomoji.Faces,
+		Exclamations:         kaomoji.Exclamations,
+		Actions:              kaomoji.Actions,
 		wordsModifier:        DefaultWords,
 		spacesModifier:       DefaultSpaces,
 		exclamationsModifier: DefaultExclamations,
+		algorithm:            DefaultAlgorithmVersion,
+		triggerLimit:         1,
+		faceMarker:           DefaultFaceMarker,
+		derived:              newDerivedSet(),
 	}
 
 	// Initialize uwu replacement patterns
 	u.uwuMap = []UwuReplacement{
-		{regexp.MustCompile(`ove`), "uv"},          // Do this FIRST
-		{regexp.MustCompile(`[rl]`), "w"},          // Lowercase r/l -> w
-		{regexp.MustCompile(`[RL]`), "W"},          // Uppercase R/L -> W
-		{regexp.MustCompile(`n([aeiou])`), "ny$1"}, // n + vowel -> ny + vowel
-		{regexp.MustCompile(`N([aeiou])`), "Ny$1"}, // N + vowel -> Ny + vowel
-		{regexp.MustCompile(`N([AEIOU])`), "NY$1"}, // N + VOWEL -> NY + VOWEL
+		literalRule("ove", "uv"),     // Do this FIRST
+		classRule("rl", 'w'),         // Lowercase r/l -> w
+		classRule("RL", 'W'),         // Uppercase R/L -> W
+		pairRule('n', "aeiou", "ny"), // n + vowel -> ny + vowel
+		pairRule('N', "aeiou", "Ny"), // N + vowel -> Ny + vowel
+		pairRule('N', "AEIOU", "NY"), // N + VOWEL -> NY + VOWEL
 	}
 
 	// Apply options
@@ -100,6 +227,54 @@ func New(opts ...Option) *Uwuifier {
 	return u
 }
 
+// Clone returns a copy of the uwuifier that shares no state with it, so
+// either can be reconfigured without affecting the other. A cache is not
+// copied; the clone starts with an empty one of the same size.
+func (u *Uwuifier) Clone() *Uwuifier {
+	c := *u
+	c.Faces = slices.Clone(u.Faces)
+	c.Exclamations = slices.Clone(u.Exclamations)
+	c.Actions = slices.Clone(u.Actions)
+	c.uwuMap = slices.Clone(u.uwuMap)
+	c.rules = slices.Clone(u.rules)
+	c.phoneticRules = slices.Clone(u.phoneticRules)
+	c.stutterCounts = slices.Clone(u.stutterCounts)
+	c.dictionary = maps.Clone(u.dictionary)
+	c.protected = maps.Clone(u.protected)
+	c.faceWeights = maps.Clone(u.faceWeights)
+	c.actionWeights = maps.Clone(u.actionWeights)
+	c.exclamationWeights = maps.Clone(u.exclamationWeights)
+	c.ratings = maps.Clone(u.ratings)
+	c.moods = u.Moods()
+	c.lexicon = maps.Clone(u.lexicon)
+	c.triggers = u.Triggers()
+	c.triggerIndex = maps.Clone(u.triggerIndex)
+	c.descriptions = maps.Clone(u.descriptions)
+	c.personas = maps.Clone(u.personas)
+	c.derived = newDerivedSet()
+	if u.cache != nil {
+		c.cache = newWordCache(u.cache.capacity)
+	}
+	return &c
+}
+
+// With returns a clone of the uwuifier with the options applied, leaving
+// the original untouched
+func (u *Uwuifier) With(opts ...Option) *Uwuifier {
+	c := u.Clone()
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// changed drops everything derived from the configuration of the
+// uwuifier: cached words and the uwuifiers of its personas
+func (u *Uwuifier) changed() {
+	u.cache.reset()
+	u.derived.reset()
+}
+
 // Getters
 func (u *Uwuifier) WordsModifier() float64         { return u.wordsModifier }
 func (u *Uwuifier) SpacesModifier() SpacesModifier { return u.spacesModifier }
@@ -111,6 +286,7 @@ func (u *Uwuifier) SetWordsModifier(value float64) error {
 		return errors.New("wordsModifier value must be between 0 and 1")
 	}
 	u.wordsModifier = value
+	u.changed()
 	return nil
 }
 
@@ -120,6 +296,7 @@ func (u *Uwuifier) SetSpacesModifier(value SpacesModifier) error {
 		return errors.New("spacesModifier sum must be between 0 and 1")
 	}
 	u.spacesModifier = value
+	u.changed()
 	return nil
 }
 
@@ -128,132 +305,26 @@ func (u *Uwuifier) SetExclamationsModifier(value float64) error {
 		return errors.New("exclamationsModifier value must be between 0 and 1")
 	}
 	u.exclamationsModifier = value
+	u.changed()
 	return nil
 }
 
 // UwuifyWords transforms words using regex patterns
 func (u *Uwuifier) UwuifyWords(sentence string) string {
-	words := strings.Split(sentence, " ")
-
-	for i, word := range words {
-		if isAt(word) || isURI(word) {
-			continue
-		}
-
-		seed := NewSeed(word)
-
-		for _, replacement := range u.uwuMap {
-			// Generate random value for each pattern
-			randVal, _ := seed.Random(0, 1)
-			if randVal > u.wordsModifier {
-				continue
-			}
-
-			word = replacement.Pattern.ReplaceAllString(word, replacement.Replacement)
-		}
-
-		words[i] = word
-	}
-
-	return strings.Join(words, " ")
+	return u.uwuifyString(sentence, stageWords)
 }
 
 // UwuifySpaces transforms spaces by adding faces, actions, or stutters
 func (u *Uwuifier) UwuifySpaces(sentence string) string {
-	words := strings.Split(sentence, " ")
-
-	faceThreshold := u.spacesModifier.Faces
-	actionThreshold := u.spacesModifier.Actions + faceThreshold
-	stutterThreshold := u.spacesModifier.Stutters + actionThreshold
-
-	for i, word := range words {
-		if word == "" {
-			continue
-		}
-
-		seed := NewSeed(word)
-		randVal, _ := seed.Random(0, 1)
-
-		firstChar := string(word[0])
-
-		checkCapital := func() {
-			// Check if we should remove the first capital letter
-			if firstChar != strings.ToUpper(firstChar) {
-				return
-			}
-			// If word has higher than 50% upper case
-			if getCapitalPercentage(word) > 0.5 {
-				return
-			}
-
-			// If it's the first word
-			if i == 0 {
-				word = strings.ToLower(firstChar) + word[1:]
-			} else {
-				prevWord := words[i-1]
-				if len(prevWord) > 0 {
-					lastChar := prevWord[len(prevWord)-1]
-					punctuation := regexp.MustCompile(`[.!?\-]`)
-					if punctuation.MatchString(string(lastChar)) {
-						word = strings.ToLower(firstChar) + word[1:]
-					}
-				}
-			}
-		}
-
-		if randVal <= faceThreshold && len(u.Faces) > 0 && !isBreak(word) {
-			// Add random face
-			faceIdx, _ := seed.RandomInt(0, len(u.Faces)-1)
-			word += " " + u.Faces[faceIdx]
-			checkCapital()
-		} else if randVal <= actionThreshold && len(u.Actions) > 0 && !isBreak(word) {
-			// Add random action
-			actionIdx, _ := seed.RandomInt(0, len(u.Actions)-1)
-			word += " " + u.Actions[actionIdx]
-			checkCapital()
-		} else if randVal <= stutterThreshold && !isURI(word) && !isBreak(word) {
-			// Add stutter
-			stutterCount, _ := seed.RandomInt(0, 2)
-			stutter := strings.Repeat(firstChar+"-", stutterCount)
-			word = stutter + word
-		}
-
-		words[i] = word
-	}
-
-	return strings.Join(words, " ")
+	return u.uwuifyString(sentence, stageSpaces)
 }
 
 // UwuifyExclamations replaces exclamations with more expressive ones
 func (u *Uwuifier) UwuifyExclamations(sentence string) string {
-	words := strings.Split(sentence, " ")
-	pattern := regexp.MustCompile(`[?!]+$`)
-
-	for i, word := range words {
-		seed := NewSeed(word)
-		randVal, _ := seed.Random(0, 1)
-
-		if !pattern.MatchString(word) ||
-			randVal > u.exclamationsModifier ||
-			isBreak(word) {
-			continue
-		}
-
-		word = pattern.ReplaceAllString(word, "")
-		exclamationIdx, _ := seed.RandomInt(0, len(u.Exclamations)-1)
-		word += u.Exclamations[exclamationIdx]
-
-		words[i] = word
-	}
-
-	return strings.Join(words, " ")
+	return u.uwuifyString(sentence, stageExclamations)
 }
 
 // UwuifySentence applies all transformations to a sentence
 func (u *Uwuifier) UwuifySentence(sentence string) string {
-	result := sentence
-	result = u.UwuifyWords(result)
-	result = u.UwuifyExclamations(result)
-	result = u.UwuifySpaces(result)
-	return result
+	return u.uwuifyString(sentence, stageAll)
 }
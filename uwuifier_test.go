@@ -178,3 +178,95 @@ func TestTransformationOccurs(t *testing.T) {
 		t.Errorf("Expected 'w' replacements in result: %q", result)
 	}
 }
+
+func BenchmarkUwuifySentence(b *testing.B) {
+	uwuifier := New()
+	sentence := "Hello world! This is a test sentence with https://example.com and @someone, really?!"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		uwuifier.UwuifySentence(sentence)
+	}
+}
+
+func TestExpressionOptions(t *testing.T) {
+	uwuifier := New(
+		WithFaces("OwO", "UwU"),
+		WithExtraFaces("UwU", ">w<"),
+		WithoutFaces("OwO"),
+		WithActions("*blushes*"),
+		WithExtraActions("*nuzzles*"),
+		WithoutActions("*blushes*"),
+		WithExclamationList("!!", "?!"),
+		WithExtraExclamations("!?!"),
+		WithoutExclamations("!!"),
+	)
+
+	testCases := []struct {
+		name     string
+		result   []string
+		expected []string
+	}{
+		{"Faces", uwuifier.Faces, []string{"UwU", ">w<"}},
+		{"Actions", uwuifier.Actions, []string{"*nuzzles*"}},
+		{"Exclamations", uwuifier.Exclamations, []string{"?!", "!?!"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if strings.Join(tc.result, " ") != strings.Join(tc.expected, " ") {
+				t.Errorf("%s = %q, want %q", tc.name, tc.result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestWithoutAllExpressions(t *testing.T) {
+	uwuifier := New(
+		WithoutFaces(),
+		WithoutActions(),
+		WithoutExclamations(),
+		WithSpaces(SpacesModifier{Faces: 0.5, Actions: 0.5}),
+	)
+
+	input := "Hello world!"
+	if result := uwuifier.UwuifySentence(input); strings.ContainsAny(result, "*?") ||
+		len(strings.Fields(result)) != 2 {
+		t.Errorf("UwuifySentence(%q) = %q, want no expressions", input, result)
+	}
+}
+
+func TestOptionsCopyLists(t *testing.T) {
+	faces := []string{"OwO", "UwU"}
+	uwuifier := New(WithFaces(faces...))
+
+	faces[0] = "changed"
+	if uwuifier.Faces[0] != "OwO" {
+		t.Errorf("WithFaces kept a reference to the caller's slice: %q", uwuifier.Faces)
+	}
+}
+
+func TestCloneAndWith(t *testing.T) {
+	original := New(WithCache(16))
+	derived := original.With(WithWords(0), WithExtraFaces("^_^"))
+
+	if original.WordsModifier() != DefaultWords {
+		t.Errorf("With changed the original words modifier to %v", original.WordsModifier())
+	}
+	if derived.WordsModifier() != 0 {
+		t.Errorf("derived words modifier = %v, want 0", derived.WordsModifier())
+	}
+	if len(original.Faces) == len(derived.Faces) {
+		t.Errorf("With changed the original faces: %q", original.Faces)
+	}
+
+	clone := original.Clone()
+	clone.Actions[0] = "*changed*"
+	if original.Actions[0] == "*changed*" {
+		t.Error("Clone shares its Actions with the original")
+	}
+	if clone.CacheStats().Capacity != 16 {
+		t.Errorf("clone cache capacity = %d, want 16", clone.CacheStats().Capacity)
+	}
+}
@@ -0,0 +1,57 @@
+package gouwu
+
+import "errors"
+
+// AlgorithmVersion identifies a version of the transformation algorithm.
+//
+// A version pins everything that decides the output for a given
+// configuration: the replacement rules, the default faces, actions and
+// exclamations and their order, how words are seeded and how choices are
+// drawn from the seed. Any change to these ships as a new version, so text
+// uwuified under one version can always be reproduced by pinning it.
+type AlgorithmVersion int
+
+const (
+	// AlgorithmV1 is the original port. It picks faces, actions,
+	// exclamations and stutter lengths by rounding a random float, which
+	// gives the first and last choice half the chance of the others.
+	AlgorithmV1 AlgorithmVersion = 1
+
+	// AlgorithmV2 makes every face, action, exclamation and stutter length
+	// equally likely.
+	AlgorithmV2 AlgorithmVersion = 2
+
+	// AlgorithmV3 makes stutters repeat the consonants starting a word,
+	// like "th-this", rather than its first byte, skips punctuation before
+	// them and leaves numbers alone.
+	AlgorithmV3 AlgorithmVersion = 3
+
+	// LatestAlgorithmVersion is the newest version, which uwuifiers use once
+	// they opt into it
+	LatestAlgorithmVersion = AlgorithmV3
+
+	// DefaultAlgorithmVersion is the version new uwuifiers use, so that
+	// upgrading never changes the output for a seed. Newer versions are
+	// opted into with WithAlgorithmVersion.
+	DefaultAlgorithmVersion = AlgorithmV1
+)
+
+// WithAlgorithmVersion pins the version of the transformation algorithm
+func WithAlgorithmVersion(version AlgorithmVersion) Option {
+	return func(u *Uwuifier) {
+		u.SetAlgorithmVersion(version)
+	}
+}
+
+// AlgorithmVersion returns the version of the transformation algorithm in use
+func (u *Uwuifier) AlgorithmVersion() AlgorithmVersion { return u.algorithm }
+
+// SetAlgorithmVersion pins the version of the transformation algorithm
+func (u *Uwuifier) SetAlgorithmVersion(version AlgorithmVersion) error {
+	if version < AlgorithmV1 || version > LatestAlgorithmVersion {
+		return errors.New("unsupported algorithm version")
+	}
+	u.algorithm = version
+	u.changed()
+	return nil
+}
@@ -0,0 +1,36 @@
+package gouwu
+
+import "testing"
+
+func TestAlgorithmVersion(t *testing.T) {
+	if version := New().AlgorithmVersion(); version != DefaultAlgorithmVersion {
+		t.Errorf("New().AlgorithmVersion() = %d, want %d", version, DefaultAlgorithmVersion)
+	}
+
+	if version := New(WithAlgorithmVersion(AlgorithmV1)).AlgorithmVersion(); version != AlgorithmV1 {
+		t.Errorf("AlgorithmVersion() = %d, want %d", version, AlgorithmV1)
+	}
+
+	uwuifier := New()
+	for _, version := range []AlgorithmVersion{0, -1, LatestAlgorithmVersion + 1} {
+		if err := uwuifier.SetAlgorithmVersion(version); err == nil {
+			t.Errorf("SetAlgorithmVersion(%d) expected error but got none", version)
+		}
+	}
+	if uwuifier.AlgorithmVersion() != DefaultAlgorithmVersion {
+		t.Errorf("a rejected version was applied: %d", uwuifier.AlgorithmVersion())
+	}
+}
+
+func TestAlgorithmVersionClearsCache(t *testing.T) {
+	uwuifier := New(WithCache(16), WithSpaces(SpacesModifier{Faces: 1}))
+	input := "Hello world! This is a test sentence?!"
+
+	uwuifier.UwuifySentence(input)
+	uwuifier.SetAlgorithmVersion(LatestAlgorithmVersion)
+
+	expected := New(WithAlgorithmVersion(LatestAlgorithmVersion), WithSpaces(SpacesModifier{Faces: 1})).UwuifySentence(input)
+	if result := uwuifier.UwuifySentence(input); result != expected {
+		t.Errorf("after SetAlgorithmVersion got %q, want %q", result, expected)
+	}
+}
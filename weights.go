@@ -0,0 +1,128 @@
+package gouwu
+
+import (
+	"errors"
+	"maps"
+	"math"
+)
+
+// Weights maps faces, actions or exclamations to how likely they are to be
+// picked relative to each other. Expressions missing from the map weigh 1,
+// and a weight of 0 means the expression is never picked.
+type Weights map[string]float64
+
+// WithFaceWeights sets the relative weights of the faces
+func WithFaceWeights(weights Weights) Option {
+	return func(u *Uwuifier) {
+		u.SetFaceWeights(weights)
+	}
+}
+
+// WithActionWeights sets the relative weights of the actions
+func WithActionWeights(weights Weights) Option {
+	return func(u *Uwuifier) {
+		u.SetActionWeights(weights)
+	}
+}
+
+// WithExclamationWeights sets the relative weights of the exclamations
+func WithExclamationWeights(weights Weights) Option {
+	return func(u *Uwuifier) {
+		u.SetExclamationWeights(weights)
+	}
+}
+
+// FaceWeights returns a copy of the face weights
+func (u *Uwuifier) FaceWeights() Weights { return maps.Clone(u.faceWeights) }
+
+// ActionWeights returns a copy of the action weights
+func (u *Uwuifier) ActionWeights() Weights { return maps.Clone(u.actionWeights) }
+
+// ExclamationWeights returns a copy of the exclamation weights
+func (u *Uwuifier) ExclamationWeights() Weights { return maps.Clone(u.exclamationWeights) }
+
+// SetFaceWeights sets the relative weights of the faces
+func (u *Uwuifier) SetFaceWeights(weights Weights) error {
+	if err := weights.validate(); err != nil {
+		return err
+	}
+	u.faceWeights = maps.Clone(weights)
+	u.changed()
+	return nil
+}
+
+// SetActionWeights sets the relative weights of the actions
+func (u *Uwuifier) SetActionWeights(weights Weights) error {
+	if err := weights.validate(); err != nil {
+		return err
+	}
+	u.actionWeights = maps.Clone(weights)
+	u.changed()
+	return nil
+}
+
+// SetExclamationWeights sets the relative weights of the exclamations
+func (u *Uwuifier) SetExclamationWeights(weights Weights) error {
+	if err := weights.validate(); err != nil {
+		return err
+	}
+	u.exclamationWeights = maps.Clone(weights)
+	u.changed()
+	return nil
+}
+
+// validate checks that every weight is a finite, non-negative number
+func (w Weights) validate() error {
+	for _, weight := range w {
+		if weight < 0 || math.IsInf(weight, 0) || math.IsNaN(weight) {
+			return errors.New("weights must be finite and not negative")
+		}
+	}
+	return nil
+}
+
+// of returns the weight of expression
+func (w Weights) of(expression string) float64 {
+	if weight, ok := w[expression]; ok {
+		return weight
+	}
+	return 1
+}
+
+// available reports whether anything in list can be picked
+func (w Weights) available(list []string, f filter) bool {
+	for _, expression := range list {
+		if w.of(expression) > 0 && f.allows(expression) {
+			return true
+		}
+	}
+	return false
+}
+
+// pick selects an expression from list that f allows. Weighted expressions
+// are picked with Seed.WeightedPick; without weights every expression is
+// equally likely, or picked with the rounded RandomInt of the original port
+// if legacy is set. The caller makes sure something in list is available.
+func (w Weights) pick(r *wordRand, list []string, legacy bool, f filter) string {
+	if len(w) == 0 {
+		n := f.count(list)
+		if legacy {
+			return f.nth(list, r.roundedInt(0, n-1))
+		}
+		return f.nth(list, r.intn(n))
+	}
+
+	weight := func(i int) float64 {
+		if !f.allows(list[i]) {
+			return 0
+		}
+		return w.of(list[i])
+	}
+
+	var total float64
+	for i := range list {
+		total += weight(i)
+	}
+
+	return list[r.weightedIndex(len(list), total, weight)]
+}
@@ -0,0 +1,109 @@
+package gouwu
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWeightedFaces(t *testing.T) {
+	uwuifier := New(
+		WithWords(0),
+		WithSpaces(SpacesModifier{Faces: 1}),
+		WithFaces("UwU", "ÚwÚ", "OwO"),
+		WithFaceWeights(Weights{"UwU": 20, "ÚwÚ": 0}),
+	)
+
+	counts := map[string]int{}
+	for i := 0; i < 2000; i++ {
+		result := uwuifier.UwuifySpaces(fmt.Sprintf("word%d", i))
+		counts[result[strings.IndexByte(result, ' ')+1:]]++
+	}
+
+	if counts["ÚwÚ"] != 0 {
+		t.Errorf("face with weight 0 was picked %d times", counts["ÚwÚ"])
+	}
+	if counts["UwU"] < 10*counts["OwO"] {
+		t.Errorf("UwU picked %d times, OwO %d times, want roughly 20:1", counts["UwU"], counts["OwO"])
+	}
+}
+
+func TestWeightedSelectionDeterminism(t *testing.T) {
+	uwuifier := New(
+		WithSpaces(SpacesModifier{Faces: 0.5, Actions: 0.5}),
+		WithFaceWeights(Weights{"UwU": 5}),
+		WithActionWeights(Weights{"*blushes*": 5}),
+		WithExclamationWeights(Weights{"!!11": 5}),
+	)
+
+	input := "Hello world! This is a test sentence?! Really!"
+	if uwuifier.UwuifySentence(input) != uwuifier.UwuifySentence(input) {
+		t.Error("weighted selection is not deterministic")
+	}
+}
+
+func TestZeroWeightsDisablePool(t *testing.T) {
+	uwuifier := New(
+		WithWords(0),
+		WithSpaces(SpacesModifier{Faces: 1}),
+		WithoutActions(),
+		WithFaces("UwU"),
+		WithFaceWeights(Weights{"UwU": 0}),
+		WithExclamationWeights(Weights{"!?": 0, "?!!": 0, "?!?1": 0, "!!11": 0, "?!?!": 0}),
+	)
+
+	// With no face or action to pick every word falls through to stutters
+	input := "Hello world!"
+	if result := uwuifier.UwuifySentence(input); strings.Contains(result, "UwU") ||
+		!strings.HasSuffix(result, "world!") {
+		t.Errorf("UwuifySentence(%q) = %q, want no faces or exclamations", input, result)
+	}
+}
+
+func TestWeightsValidation(t *testing.T) {
+	testCases := []struct {
+		weights Weights
+		wantErr bool
+	}{
+		{nil, false},
+		{Weights{"UwU": 2, "OwO": 0}, false},
+		{Weights{"UwU": -1}, true},
+		{Weights{"UwU": math.Inf(1)}, true},
+		{Weights{"UwU": math.NaN()}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprint(tc.weights), func(t *testing.T) {
+			uwuifier := New()
+			for _, set := range []func(Weights) error{
+				uwuifier.SetFaceWeights,
+				uwuifier.SetActionWeights,
+				uwuifier.SetExclamationWeights,
+			} {
+				err := set(tc.weights)
+				if tc.wantErr && err == nil {
+					t.Error("Expected error but got none")
+				}
+				if !tc.wantErr && err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestWeightsAreCopied(t *testing.T) {
+	weights := Weights{"UwU": 3}
+	uwuifier := New(WithFaceWeights(weights))
+
+	weights["UwU"] = 0
+	if uwuifier.FaceWeights()["UwU"] != 3 {
+		t.Errorf("WithFaceWeights kept a reference to the caller's map")
+	}
+
+	uwuifier.FaceWeights()["UwU"] = 0
+	if uwuifier.FaceWeights()["UwU"] != 3 {
+		t.Errorf("FaceWeights returned the internal map")
+	}
+}